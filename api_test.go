@@ -2,11 +2,40 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
+
+	"github-api-demo/internal/cassette"
 )
 
+var update = flag.Bool("update", false, "regrava testdata/github_api.json acessando a API real do GitHub")
+
+// TestMain faz as chamadas ao GitHub passarem pela cassete testdata/github_api.json,
+// para que os testes rodem sem rede. Com -update, grava as respostas reais.
+// Rode com: go test api.go api_test.go
+func TestMain(m *testing.M) {
+	flag.Parse()
+	mode := cassette.Replay
+	if *update {
+		mode = cassette.Record
+	}
+	rec, err := cassette.New("testdata/github_api.json", mode, http.DefaultTransport)
+	if err != nil {
+		log.Fatal(err)
+	}
+	http.DefaultTransport = rec
+
+	code := m.Run()
+	if err := rec.Save(); err != nil {
+		log.Fatalf("erro ao salvar cassete: %v", err)
+	}
+	os.Exit(code)
+}
+
 // TestHomeHandler testa o endpoint raiz
 func TestHomeHandler(t *testing.T) {
 	req, err := http.NewRequest("GET", "/", nil)
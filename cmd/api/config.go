@@ -0,0 +1,110 @@
+package main
+
+import (
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github-api-demo/internal/clients"
+	"github-api-demo/internal/logging"
+	"github-api-demo/internal/tracing"
+)
+
+// newLogger cria o logger estruturado: LOG_FORMAT escolhe "text" (padrão) ou
+// "json" e LOG_LEVEL o nível mínimo (debug, info, warn, error)
+func newLogger() *slog.Logger {
+	return logging.New(os.Stderr, os.Getenv("LOG_FORMAT"), os.Getenv("LOG_LEVEL"))
+}
+
+// httpTransport cria o transporte compartilhado pelos clientes das APIs externas.
+// O proxy vem de HTTPS_PROXY/HTTP_PROXY/NO_PROXY e o pool de conexões pode ser
+// ajustado com HTTP_MAX_IDLE_CONNS_PER_HOST.
+func httpTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = envInt("HTTP_MAX_IDLE_CONNS_PER_HOST", 10)
+	return transport
+}
+
+// clientOptions monta as opções de um cliente a partir de <PREFIX>_BASE_URL,
+// <PREFIX>_TIMEOUT e HTTP_USER_AGENT
+func clientOptions(prefix string, transport http.RoundTripper) []clients.Option {
+	opts := []clients.Option{clients.WithTransport(transport)}
+	if v := os.Getenv(prefix + "_BASE_URL"); v != "" {
+		opts = append(opts, clients.WithBaseURL(v))
+	}
+	if d := envDuration(prefix+"_TIMEOUT", 0); d > 0 {
+		opts = append(opts, clients.WithTimeout(d))
+	}
+	if v := os.Getenv("HTTP_USER_AGENT"); v != "" {
+		opts = append(opts, clients.WithUserAgent(v))
+	}
+	return opts
+}
+
+// traceExporter escolhe o exportador de spans por OTEL_TRACES_EXPORTER: "none"
+// (padrão, sem exportação), "stdout" (uma linha JSON por span, para depuração) ou
+// "otlp" (OTLP/HTTP para OTEL_EXPORTER_OTLP_TRACES_ENDPOINT ou
+// OTEL_EXPORTER_OTLP_ENDPOINT + /v1/traces, com o serviço em OTEL_SERVICE_NAME)
+func traceExporter() tracing.Exporter {
+	switch v := os.Getenv("OTEL_TRACES_EXPORTER"); v {
+	case "", "none":
+		return nil
+	case "stdout", "console":
+		return tracing.NewStdoutExporter(os.Stdout)
+	case "otlp":
+		url := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+		if url == "" {
+			endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+			if endpoint == "" {
+				endpoint = "http://localhost:4318"
+			}
+			url = strings.TrimSuffix(endpoint, "/") + "/v1/traces"
+		}
+		service := os.Getenv("OTEL_SERVICE_NAME")
+		if service == "" {
+			service = "tvmaze-api"
+		}
+		return tracing.NewOTLPExporter(url, service)
+	default:
+		log.Printf("⚠️  OTEL_TRACES_EXPORTER inválido: %q, tracing desativado", v)
+		return nil
+	}
+}
+
+// staleMaxAge lê STALE_MAX_AGE (ex: "6h"), a idade máxima da programação servida
+// quando a API do TVMaze está indisponível
+func staleMaxAge() time.Duration {
+	return envDuration("STALE_MAX_AGE", 6*time.Hour)
+}
+
+// envDuration lê uma duração (ex: "30s") da variável de ambiente, com valor padrão
+func envDuration(name string, fallback time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("⚠️  %s inválido: %q, usando %v", name, v, fallback)
+		return fallback
+	}
+	return d
+}
+
+// envInt lê um inteiro da variável de ambiente, com valor padrão
+func envInt(name string, fallback int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("⚠️  %s inválido: %q, usando %d", name, v, fallback)
+		return fallback
+	}
+	return n
+}
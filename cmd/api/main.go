@@ -3,33 +3,49 @@ package main
 import (
 	"context"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // fusos horários embutidos; a imagem alpine não inclui tzdata
 
 	"github-api-demo/internal/clients"
 	"github-api-demo/internal/handlers"
+	"github-api-demo/internal/middleware"
 	"github-api-demo/internal/router"
 	"github-api-demo/internal/services"
+	"github-api-demo/internal/tracing"
 )
 
 func main() {
+	// Log estruturado; o pacote log também passa a escrever por ele
+	slog.SetDefault(newLogger())
+	
+	// Exportação de spans (desativada por padrão)
+	shutdownTracing := tracing.Configure(traceExporter())
+
 	// Inicializar clientes
-	tvmazeClient := clients.NewTVMazeClient()
-	githubClient := clients.NewGitHubClient()
+	transport := httpTransport()
+	tvmazeAPI := clients.NewTVMazeClient(clientOptions("TVMAZE", transport)...)
+	tvmazeClient := clients.NewCachedTVMazeClient(tvmazeAPI, clients.DefaultCacheConfig())
+	githubClient := clients.NewGitHubClient(clientOptions("GITHUB", transport)...)
 	
 	// Inicializar serviços
 	tvmazeService := services.NewTVMazeService(tvmazeClient)
+	tvmazeService.SetStaleStore(services.NewStaleStore(staleMaxAge()))
+	peopleService := services.NewPeopleService(tvmazeClient)
 	githubService := services.NewGitHubService(githubClient)
 	
 	// Inicializar handlers
 	tvmazeHandler := handlers.NewTVMazeHandler(tvmazeService)
+	peopleHandler := handlers.NewPeopleHandler(peopleService)
 	githubHandler := handlers.NewGitHubHandler(githubService)
+	diagnosticsHandler := handlers.NewDiagnosticsHandler(tvmazeAPI, githubClient)
 	
 	// Configurar rotas
-	mux := router.Setup(tvmazeHandler, githubHandler)
+	mux := router.Setup(tvmazeHandler, peopleHandler, githubHandler, diagnosticsHandler)
 	
 	// Configurar servidor
 	port := os.Getenv("PORT")
@@ -37,11 +53,14 @@ func main() {
 		port = "8080"
 	}
 	
+	writeTimeout := 15 * time.Second
+	
 	server := &http.Server{
 		Addr:         ":" + port,
-		Handler:      mux,
+		// As requisições têm 1s a menos que o WriteTimeout para responder a tempo
+		Handler:      middleware.RequestID(middleware.Timeout(writeTimeout-time.Second, mux)),
 		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
+		WriteTimeout: writeTimeout,
 		IdleTimeout:  60 * time.Second,
 	}
 	
@@ -51,10 +70,10 @@ func main() {
 	
 	// Iniciar servidor em goroutine
 	go func() {
-		log.Printf("🚀 Servidor iniciado na porta %s", port)
-		log.Printf("📚 Documentação: http://localhost:%s/docs", port)
-		log.Printf("📡 API TVMaze: http://localhost:%s/schedule", port)
-		log.Printf("🐙 API GitHub: http://localhost:%s/api/user?username=patrickbathu", port)
+		slog.Info("🚀 Servidor iniciado", "port", port)
+		slog.Info("📚 Documentação", "url", "http://localhost:"+port+"/docs")
+		slog.Info("📡 API TVMaze", "url", "http://localhost:"+port+"/schedule")
+		slog.Info("🐙 API GitHub", "url", "http://localhost:"+port+"/api/user?username=patrickbathu")
 		
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("❌ Erro ao iniciar servidor: %v", err)
@@ -63,7 +82,7 @@ func main() {
 	
 	// Aguardar sinal de shutdown
 	<-quit
-	log.Println("🛑 Shutdown solicitado...")
+	slog.Info("🛑 Shutdown solicitado...")
 	
 	// Graceful shutdown com timeout de 30 segundos
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -73,5 +92,10 @@ func main() {
 		log.Fatalf("❌ Erro durante shutdown: %v", err)
 	}
 	
-	log.Println("✅ Servidor encerrado com sucesso")
+	// Enviar os spans ainda pendentes
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Warn("spans pendentes não exportados", "error", err)
+	}
+
+	slog.Info("✅ Servidor encerrado com sucesso")
 }
@@ -0,0 +1,31 @@
+// Command fakemaze sobe um substituto local das APIs do TVMaze e do GitHub,
+// para rodar a API inteira sem rede:
+//
+//	go run ./cmd/fakemaze -addr :9090 -latency 200ms -error-rate 0.1
+//	TVMAZE_BASE_URL=http://localhost:9090 GITHUB_BASE_URL=http://localhost:9090 go run ./cmd/api
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	_ "time/tzdata" // fusos das emissoras para recalcular os airstamps
+
+	"github-api-demo/internal/fakemaze"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "endereço de escuta")
+	var config fakemaze.Config
+	flag.DurationVar(&config.Latency, "latency", 0, "latência adicionada a cada resposta (ex: 200ms)")
+	flag.Float64Var(&config.RateLimitRate, "rate-limit-rate", 0, "fração das requisições respondidas com 429 (0 a 1)")
+	flag.Float64Var(&config.ErrorRate, "error-rate", 0, "fração das requisições respondidas com 503 (0 a 1)")
+	flag.DurationVar(&config.RetryAfter, "retry-after", 0, "Retry-After enviado nas respostas 429 (ex: 2s)")
+	flag.Parse()
+
+	log.Printf("🎭 fakemaze ouvindo em %s (latência %v, 429 %.0f%%, 5xx %.0f%%)",
+		*addr, config.Latency, config.RateLimitRate*100, config.ErrorRate*100)
+	if err := http.ListenAndServe(*addr, fakemaze.New(config)); err != nil {
+		log.Fatalf("❌ Erro ao iniciar servidor: %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+// Package cassette implementa um http.RoundTripper que grava as trocas com as
+// APIs externas em um arquivo JSON (a "cassete") e depois as reproduz nos testes,
+// sem rede. Conecte-o aos clientes com clients.WithTransport.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Mode define se o Recorder grava respostas reais ou reproduz as gravadas
+type Mode int
+
+const (
+	// Replay responde apenas com as interações da cassete, sem acessar a rede
+	Replay Mode = iota
+	// Record repassa as requisições ao transporte real e grava as respostas
+	Record
+)
+
+// recordedHeaders são os únicos headers de resposta gravados; o resto é ruído
+// (datas, limites de requisição) e mudaria a cassete a cada gravação
+var recordedHeaders = []string{"Content-Type", "Retry-After"}
+
+// Interaction é uma requisição gravada e sua resposta
+type Interaction struct {
+	Method string              `json:"method"`
+	URL    string              `json:"url"`
+	Status int                 `json:"status"`
+	Header map[string][]string `json:"header,omitempty"`
+	Body   json.RawMessage     `json:"body,omitempty"`
+	Text   string              `json:"text,omitempty"`
+}
+
+// Recorder é o http.RoundTripper que grava ou reproduz uma cassete
+type Recorder struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New cria um Recorder para a cassete em path. Em Replay a cassete precisa existir;
+// em Record as requisições vão para next (http.DefaultTransport quando nil).
+func New(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	r := &Recorder{path: path, mode: mode, next: next}
+
+	if mode == Replay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cassete não encontrada (grave com -update): %w", err)
+		}
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("cassete inválida %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.interactions))
+	}
+	return r, nil
+}
+
+// RoundTrip grava ou reproduz a requisição conforme o modo
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == Record {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+// record repassa a requisição e guarda a resposta na cassete
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Status: resp.StatusCode,
+		Header: make(map[string][]string),
+	}
+	for _, name := range recordedHeaders {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			interaction.Header[name] = values
+		}
+	}
+	if json.Valid(body) {
+		interaction.Body = body
+	} else {
+		interaction.Text = string(body)
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+
+	return interaction.response(req), nil
+}
+
+// replay procura a primeira interação ainda não usada com o mesmo método, caminho
+// e query (o host é ignorado, para funcionar com qualquer URL base). Quando todas
+// já foram usadas, repete a última.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, interaction := range r.interactions {
+		if interaction.Method != req.Method || !sameRequestURI(interaction.URL, req) {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("cassete %s não tem gravação para %s %s", filepath.Base(r.path), req.Method, req.URL.RequestURI())
+	}
+
+	r.used[match] = true
+	return r.interactions[match].response(req), nil
+}
+
+// sameRequestURI compara caminho e query da URL gravada com os da requisição
+func sameRequestURI(recorded string, req *http.Request) bool {
+	u, err := req.URL.Parse(recorded)
+	return err == nil && u.RequestURI() == req.URL.RequestURI()
+}
+
+// response monta a resposta HTTP a partir da interação gravada
+func (i Interaction) response(req *http.Request) *http.Response {
+	body := []byte(i.Text)
+	if len(i.Body) > 0 {
+		var compact bytes.Buffer
+		json.Compact(&compact, i.Body)
+		body = compact.Bytes()
+	}
+	header := make(http.Header)
+	for name, values := range i.Header {
+		header[name] = values
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+		StatusCode:    i.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// Save grava a cassete em disco. Em Replay não faz nada.
+func (r *Recorder) Save() error {
+	if r.mode != Record {
+		return nil
+	}
+
+	// Sem escapar HTML, para que corpos como "<p>...</p>" continuem legíveis no diff
+	var data bytes.Buffer
+	enc := json.NewEncoder(&data)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	r.mu.Lock()
+	err := enc.Encode(r.interactions)
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, data.Bytes(), 0o644)
+}
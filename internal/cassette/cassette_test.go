@@ -0,0 +1,75 @@
+package cassette
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestRecorder_RecordThenReplay(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("not found"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Ratelimit-Remaining", "59")
+		w.Write([]byte(`{"id":1,"q":"` + r.URL.Query().Get("q") + `"}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassete.json")
+
+	rec, err := New(path, Record, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: rec}
+	get(t, client, srv.URL+"/shows?q=a")
+	get(t, client, srv.URL+"/missing")
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := New(path, Replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+	client = &http.Client{Transport: replay}
+
+	// O host é ignorado na reprodução
+	status, body := get(t, client, "http://outro-host/shows?q=a")
+	if status != http.StatusOK || body != `{"id":1,"q":"a"}` {
+		t.Errorf("resposta reproduzida incorreta: %d %s", status, body)
+	}
+	if status, body := get(t, client, "http://outro-host/missing"); status != http.StatusNotFound || body != "not found" {
+		t.Errorf("resposta reproduzida incorreta: %d %s", status, body)
+	}
+	if replay.interactions[0].Header["X-Ratelimit-Remaining"] != nil {
+		t.Error("headers voláteis não devem ser gravados")
+	}
+	if calls != 2 {
+		t.Errorf("a reprodução não deveria acessar o servidor, chamadas: %d", calls)
+	}
+
+	if _, err := client.Get("http://outro-host/shows?q=b"); err == nil {
+		t.Error("requisição sem gravação deveria falhar")
+	}
+}
@@ -0,0 +1,181 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github-api-demo/internal/logging"
+)
+
+// Estados do circuit breaker
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// BreakerConfig define quando o circuito abre e por quanto tempo fica aberto
+type BreakerConfig struct {
+	FailureThreshold int           // falhas consecutivas para abrir o circuito
+	OpenTimeout      time.Duration // tempo aberto antes de permitir uma requisição de teste
+}
+
+// DefaultBreakerConfig retorna a configuração padrão do circuit breaker
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+	}
+}
+
+// BreakerStats representa o estado atual de um circuit breaker
+type BreakerStats struct {
+	Upstream            string `json:"upstream"`
+	State               string `json:"state"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	RetryAfterSeconds   int    `json:"retry_after_seconds,omitempty"`
+}
+
+// CircuitOpenError é retornado quando o circuito da API externa está aberto
+// e a requisição falha imediatamente, sem ser enviada
+type CircuitOpenError struct {
+	Upstream   string
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("API %s indisponível, tente novamente em %ds", e.Upstream, e.RetryAfterSeconds())
+}
+
+// RetryAfterSeconds retorna a espera sugerida em segundos inteiros, para o header Retry-After
+func (e *CircuitOpenError) RetryAfterSeconds() int {
+	return retryAfterSeconds(e.RetryAfter)
+}
+
+// circuitBreaker implementa os estados fechado, aberto e meio-aberto para uma API externa.
+// A geração muda a cada transição de estado; resultados de requisições liberadas
+// em uma geração anterior são ignorados.
+type circuitBreaker struct {
+	mu         sync.Mutex
+	upstream   string
+	config     BreakerConfig
+	state      string
+	generation uint64
+	failures   int
+	openedAt   time.Time
+	probing    bool
+	now        func() time.Time
+}
+
+func newCircuitBreaker(upstream string, config BreakerConfig) *circuitBreaker {
+	return &circuitBreaker{
+		upstream: upstream,
+		config:   config,
+		state:    BreakerClosed,
+		now:      time.Now,
+	}
+}
+
+// allow indica se a requisição pode ser enviada e retorna a geração em que ela
+// foi liberada. Com o circuito aberto, após o OpenTimeout uma única requisição
+// de teste é liberada (meio-aberto).
+func (b *circuitBreaker) allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		elapsed := b.now().Sub(b.openedAt)
+		if elapsed < b.config.OpenTimeout {
+			return 0, &CircuitOpenError{Upstream: b.upstream, RetryAfter: b.config.OpenTimeout - elapsed}
+		}
+		b.transition(BreakerHalfOpen)
+		b.probing = true
+	case BreakerHalfOpen:
+		if b.probing {
+			return 0, &CircuitOpenError{Upstream: b.upstream, RetryAfter: time.Second}
+		}
+		b.probing = true
+	}
+	return b.generation, nil
+}
+
+// transition muda o estado e inicia uma nova geração; deve ser chamada com o lock
+func (b *circuitBreaker) transition(state string) {
+	b.state = state
+	b.generation++
+	if state == BreakerOpen {
+		b.openedAt = b.now()
+	}
+}
+
+// record registra o resultado de uma requisição liberada por allow na geração
+// informada e indica se o circuito acabou de abrir. Requisições iniciadas antes
+// da última transição não alteram o estado.
+func (b *circuitBreaker) record(generation uint64, success bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return false
+	}
+	b.probing = false
+
+	if success {
+		if b.state != BreakerClosed {
+			b.transition(BreakerClosed)
+		}
+		b.failures = 0
+		return false
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.config.FailureThreshold {
+		b.transition(BreakerOpen)
+		return true
+	}
+	return false
+}
+
+// done registra o resultado de uma requisição liberada por allow. Se a requisição
+// foi cancelada por quem a fez, o resultado não diz nada sobre a API e é ignorado.
+func (b *circuitBreaker) done(ctx context.Context, generation uint64, success bool) {
+	if !success && errors.Is(ctx.Err(), context.Canceled) {
+		b.mu.Lock()
+		if generation == b.generation {
+			b.probing = false
+		}
+		b.mu.Unlock()
+		return
+	}
+	if b.record(generation, success) {
+		logging.FromContext(ctx).Warn("circuito aberto para a API externa",
+			"upstream", b.upstream, "open_seconds", int(b.config.OpenTimeout.Seconds()))
+	}
+}
+
+// Stats retorna o estado atual do circuito
+func (b *circuitBreaker) Stats() BreakerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := BreakerStats{
+		Upstream:            b.upstream,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state == BreakerOpen {
+		if remaining := b.config.OpenTimeout - b.now().Sub(b.openedAt); remaining > 0 {
+			stats.RetryAfterSeconds = retryAfterSeconds(remaining)
+		}
+	}
+	return stats
+}
+
+// retryAfterSeconds arredonda a duração para cima, em segundos inteiros
+func retryAfterSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
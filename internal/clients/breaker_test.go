@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_Transitions(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker("tvmaze", BreakerConfig{FailureThreshold: 2, OpenTimeout: 10 * time.Second})
+	breaker.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		generation, err := breaker.allow()
+		if err != nil {
+			t.Fatalf("circuito fechado deveria liberar requisições: %v", err)
+		}
+		breaker.record(generation, false)
+	}
+
+	if state := breaker.Stats().State; state != BreakerOpen {
+		t.Fatalf("esperado circuito aberto, obtido %s", state)
+	}
+
+	var open *CircuitOpenError
+	if _, err := breaker.allow(); !errors.As(err, &open) || open.RetryAfterSeconds() != 10 {
+		t.Fatalf("circuito aberto deveria falhar imediatamente com Retry-After de 10s: %v", err)
+	}
+
+	now = now.Add(10 * time.Second)
+	probe, err := breaker.allow()
+	if err != nil {
+		t.Fatalf("após o timeout uma requisição de teste deveria ser liberada: %v", err)
+	}
+	if _, err := breaker.allow(); err == nil {
+		t.Fatal("apenas uma requisição de teste deve ser liberada no estado meio-aberto")
+	}
+
+	breaker.record(probe, false)
+	if state := breaker.Stats().State; state != BreakerOpen {
+		t.Fatalf("falha no teste deveria reabrir o circuito, obtido %s", state)
+	}
+
+	now = now.Add(10 * time.Second)
+	probe, _ = breaker.allow()
+	breaker.record(probe, true)
+
+	stats := breaker.Stats()
+	if stats.State != BreakerClosed || stats.ConsecutiveFailures != 0 {
+		t.Errorf("sucesso no teste deveria fechar o circuito: %+v", stats)
+	}
+}
+
+func TestCircuitBreaker_IgnoresRequestsStartedBeforeTrip(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker("tvmaze", BreakerConfig{FailureThreshold: 2, OpenTimeout: 10 * time.Second})
+	breaker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Três requisições saem com o circuito fechado; duas falham e abrem o circuito
+	// enquanto a terceira ainda está em andamento.
+	first, _ := breaker.allow()
+	second, _ := breaker.allow()
+	slowFailure, _ := breaker.allow()
+	slowSuccess, _ := breaker.allow()
+	breaker.done(ctx, first, false)
+	breaker.done(ctx, second, false)
+	if state := breaker.Stats().State; state != BreakerOpen {
+		t.Fatalf("esperado circuito aberto, obtido %s", state)
+	}
+
+	// A falha atrasada não pode adiar a reabertura
+	now = now.Add(6 * time.Second)
+	breaker.done(ctx, slowFailure, false)
+	if stats := breaker.Stats(); stats.RetryAfterSeconds != 4 {
+		t.Fatalf("falha de requisição anterior à abertura não deveria reiniciar o timeout: %+v", stats)
+	}
+
+	// O sucesso atrasado não pode fechar o circuito
+	breaker.done(ctx, slowSuccess, true)
+	if state := breaker.Stats().State; state != BreakerOpen {
+		t.Fatalf("sucesso de requisição anterior à abertura não deveria fechar o circuito, obtido %s", state)
+	}
+
+	now = now.Add(4 * time.Second)
+	if _, err := breaker.allow(); err != nil {
+		t.Fatalf("o circuito deveria liberar a requisição de teste no prazo original: %v", err)
+	}
+}
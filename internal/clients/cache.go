@@ -0,0 +1,119 @@
+package clients
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// lruCache é um cache em memória com TTL por entrada e limite de tamanho,
+// removendo a entrada menos usada recentemente quando o limite é atingido
+type lruCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+	now        func() time.Time
+}
+
+type cacheEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// newLRUCache cria um cache com no máximo maxEntries entradas
+func newLRUCache(maxEntries int) *lruCache {
+	return &lruCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+// Get retorna o valor armazenado para a chave, se existir e não estiver expirado
+func (c *lruCache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*cacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.removeElement(el)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(el)
+	return entry.value, true
+}
+
+// Set armazena o valor com o TTL informado, removendo a entrada mais antiga se necessário
+func (c *lruCache) Set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(ttl)
+
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		entry.value = value
+		entry.expires = expires
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&cacheEntry{key: key, value: value, expires: expires})
+
+	if c.maxEntries > 0 && c.ll.Len() > c.maxEntries {
+		c.removeElement(c.ll.Back())
+	}
+}
+
+// Len retorna o número de entradas no cache
+func (c *lruCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+func (c *lruCache) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*cacheEntry).key)
+}
+
+// CacheRecorder acumula os acertos e falhas de cache de uma requisição
+type CacheRecorder struct {
+	hits   int64
+	misses int64
+}
+
+func (r *CacheRecorder) hit() {
+	if r != nil {
+		atomic.AddInt64(&r.hits, 1)
+	}
+}
+
+func (r *CacheRecorder) miss() {
+	if r != nil {
+		atomic.AddInt64(&r.misses, 1)
+	}
+}
+
+// Status retorna "HIT" quando todas as consultas vieram do cache, "MISS" quando
+// ao menos uma foi à API, ou "" quando nenhuma consulta passou pelo cache
+func (r *CacheRecorder) Status() string {
+	hits, misses := atomic.LoadInt64(&r.hits), atomic.LoadInt64(&r.misses)
+	switch {
+	case misses > 0:
+		return "MISS"
+	case hits > 0:
+		return "HIT"
+	}
+	return ""
+}
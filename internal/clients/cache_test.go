@@ -0,0 +1,109 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github-api-demo/internal/models"
+)
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newLRUCache(2)
+
+	cache.Set("a", 1, time.Minute)
+	cache.Set("b", 2, time.Minute)
+	cache.Get("a") // "b" passa a ser o menos usado
+	cache.Set("c", 3, time.Minute)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("'b' deveria ter sido removido do cache")
+	}
+	if v, ok := cache.Get("a"); !ok || v != 1 {
+		t.Errorf("'a' deveria continuar no cache: %v", v)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("esperado 2 entradas, obtido %d", cache.Len())
+	}
+}
+
+func TestLRUCache_Expires(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	cache := newLRUCache(10)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", 1, time.Minute)
+
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("'a' deveria estar no cache")
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := cache.Get("a"); ok {
+		t.Error("'a' deveria ter expirado")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("entrada expirada deveria ser removida, obtido %d entradas", cache.Len())
+	}
+}
+
+// stubTVMaze implementa apenas GetSchedule; os demais métodos não são usados nos testes
+type stubTVMaze struct {
+	TVMazeAPI
+	calls int
+	err   error
+}
+
+func (s *stubTVMaze) GetSchedule(ctx context.Context, country, date string) ([]models.Schedule, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []models.Schedule{{ID: 1, Airdate: date}}, nil
+}
+
+func TestCachedTVMazeClient_HitAndMiss(t *testing.T) {
+	stub := &stubTVMaze{}
+	client := NewCachedTVMazeClient(stub, DefaultCacheConfig())
+	hits := cacheLookups.Value("tvmaze", "GetSchedule", "hit")
+
+	first := &CacheRecorder{}
+	if _, err := client.WithRecorder(first).GetSchedule(context.Background(), "US", "2024-01-10"); err != nil {
+		t.Fatal(err)
+	}
+	if first.Status() != "MISS" {
+		t.Errorf("primeira chamada deveria ser MISS, obtido %q", first.Status())
+	}
+
+	second := &CacheRecorder{}
+	if _, err := client.WithRecorder(second).GetSchedule(context.Background(), "US", "2024-01-10"); err != nil {
+		t.Fatal(err)
+	}
+	if second.Status() != "HIT" {
+		t.Errorf("segunda chamada deveria ser HIT, obtido %q", second.Status())
+	}
+
+	if _, err := client.GetSchedule(context.Background(), "BR", "2024-01-10"); err != nil {
+		t.Fatal(err)
+	}
+
+	if stub.calls != 2 {
+		t.Errorf("esperado 2 chamadas à API, obtido %d", stub.calls)
+	}
+	if got := cacheLookups.Value("tvmaze", "GetSchedule", "hit") - hits; got != 1 {
+		t.Errorf("esperado 1 hit na métrica, obtido %v", got)
+	}
+}
+
+func TestCachedTVMazeClient_DoesNotCacheErrors(t *testing.T) {
+	stub := &stubTVMaze{err: errors.New("falha")}
+	client := NewCachedTVMazeClient(stub, DefaultCacheConfig())
+
+	client.GetSchedule(context.Background(), "US", "2024-01-10")
+	client.GetSchedule(context.Background(), "US", "2024-01-10")
+
+	if stub.calls != 2 {
+		t.Errorf("erros não devem ser armazenados: esperado 2 chamadas, obtido %d", stub.calls)
+	}
+}
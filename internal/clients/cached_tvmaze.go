@@ -0,0 +1,183 @@
+package clients
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github-api-demo/internal/models"
+)
+
+// CacheConfig define o tamanho máximo e os TTLs por endpoint do CachedTVMazeClient
+type CacheConfig struct {
+	MaxEntries int
+	Schedule   time.Duration // /schedule e /schedule/web
+	Search     time.Duration // /search/shows e /search/people
+	Show       time.Duration // shows, episódios, temporadas, elenco e equipe
+	People     time.Duration // pessoas e créditos
+	Lookup     time.Duration // /lookup/shows
+}
+
+// DefaultCacheConfig retorna a configuração padrão do cache
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		MaxEntries: 500,
+		Schedule:   5 * time.Minute,
+		Search:     10 * time.Minute,
+		Show:       time.Hour,
+		People:     time.Hour,
+		Lookup:     24 * time.Hour,
+	}
+}
+
+// CachedTVMazeClient é um decorador que guarda em memória as respostas de outro TVMazeAPI
+type CachedTVMazeClient struct {
+	next     TVMazeAPI
+	cache    *lruCache
+	config   CacheConfig
+	recorder *CacheRecorder
+}
+
+// NewCachedTVMazeClient cria um decorador com cache em volta do cliente informado
+func NewCachedTVMazeClient(next TVMazeAPI, config CacheConfig) *CachedTVMazeClient {
+	return &CachedTVMazeClient{
+		next:   next,
+		cache:  newLRUCache(config.MaxEntries),
+		config: config,
+	}
+}
+
+// WithRecorder retorna uma visão do cliente, compartilhando o mesmo cache,
+// que registra no recorder os acertos e falhas de cada consulta
+func (c *CachedTVMazeClient) WithRecorder(recorder *CacheRecorder) TVMazeAPI {
+	clone := *c
+	clone.recorder = recorder
+	return &clone
+}
+
+// Len retorna o número de entradas no cache
+func (c *CachedTVMazeClient) Len() int {
+	return c.cache.Len()
+}
+
+// cached busca o valor no cache ou, em caso de falha, chama fetch e armazena o resultado.
+// Erros não são armazenados.
+func cached[T any](c *CachedTVMazeClient, ttl time.Duration, fetch func() (T, error), method string, args ...string) (T, error) {
+	key := method + ":" + strings.Join(args, "|")
+
+	if v, ok := c.cache.Get(key); ok {
+		c.recorder.hit()
+		cacheTotals.hit()
+		cacheLookups.Inc("tvmaze", method, "hit")
+		return v.(T), nil
+	}
+
+	c.recorder.miss()
+	cacheTotals.miss()
+	cacheLookups.Inc("tvmaze", method, "miss")
+	v, err := fetch()
+	if err != nil {
+		return v, err
+	}
+
+	c.cache.Set(key, v, ttl)
+	return v, nil
+}
+
+// GetSchedule busca a programação de um país e data
+func (c *CachedTVMazeClient) GetSchedule(ctx context.Context, country, date string) ([]models.Schedule, error) {
+	return cached(c, c.config.Schedule, func() ([]models.Schedule, error) {
+		return c.next.GetSchedule(ctx, country, date)
+	}, "GetSchedule", country, date)
+}
+
+// GetWebSchedule busca a programação de streaming de um país e data
+func (c *CachedTVMazeClient) GetWebSchedule(ctx context.Context, country, date string) ([]models.Schedule, error) {
+	return cached(c, c.config.Schedule, func() ([]models.Schedule, error) {
+		return c.next.GetWebSchedule(ctx, country, date)
+	}, "GetWebSchedule", country, date)
+}
+
+// SearchShows busca shows pelo nome
+func (c *CachedTVMazeClient) SearchShows(ctx context.Context, query string) ([]models.SearchResult, error) {
+	return cached(c, c.config.Search, func() ([]models.SearchResult, error) {
+		return c.next.SearchShows(ctx, query)
+	}, "SearchShows", query)
+}
+
+// GetShowByID busca um show específico pelo ID
+func (c *CachedTVMazeClient) GetShowByID(ctx context.Context, id string) (*models.Show, error) {
+	return cached(c, c.config.Show, func() (*models.Show, error) {
+		return c.next.GetShowByID(ctx, id)
+	}, "GetShowByID", id)
+}
+
+// LookupShow busca um show pelo ID em uma base externa
+func (c *CachedTVMazeClient) LookupShow(ctx context.Context, source, id string) (*models.Show, error) {
+	return cached(c, c.config.Lookup, func() (*models.Show, error) {
+		return c.next.LookupShow(ctx, source, id)
+	}, "LookupShow", source, id)
+}
+
+// GetEpisodes busca a lista de episódios de um show
+func (c *CachedTVMazeClient) GetEpisodes(ctx context.Context, showID string) ([]models.Episode, error) {
+	return cached(c, c.config.Show, func() ([]models.Episode, error) {
+		return c.next.GetEpisodes(ctx, showID)
+	}, "GetEpisodes", showID)
+}
+
+// GetSeasons busca as temporadas de um show
+func (c *CachedTVMazeClient) GetSeasons(ctx context.Context, showID string) ([]models.Season, error) {
+	return cached(c, c.config.Show, func() ([]models.Season, error) {
+		return c.next.GetSeasons(ctx, showID)
+	}, "GetSeasons", showID)
+}
+
+// GetSeasonEpisodes busca os episódios de uma temporada
+func (c *CachedTVMazeClient) GetSeasonEpisodes(ctx context.Context, seasonID string) ([]models.Episode, error) {
+	return cached(c, c.config.Show, func() ([]models.Episode, error) {
+		return c.next.GetSeasonEpisodes(ctx, seasonID)
+	}, "GetSeasonEpisodes", seasonID)
+}
+
+// GetCast busca o elenco de um show
+func (c *CachedTVMazeClient) GetCast(ctx context.Context, showID string) ([]models.CastCredit, error) {
+	return cached(c, c.config.Show, func() ([]models.CastCredit, error) {
+		return c.next.GetCast(ctx, showID)
+	}, "GetCast", showID)
+}
+
+// GetCrew busca a equipe técnica de um show
+func (c *CachedTVMazeClient) GetCrew(ctx context.Context, showID string) ([]models.CrewCredit, error) {
+	return cached(c, c.config.Show, func() ([]models.CrewCredit, error) {
+		return c.next.GetCrew(ctx, showID)
+	}, "GetCrew", showID)
+}
+
+// SearchPeople busca pessoas pelo nome
+func (c *CachedTVMazeClient) SearchPeople(ctx context.Context, query string) ([]models.PersonSearchResult, error) {
+	return cached(c, c.config.Search, func() ([]models.PersonSearchResult, error) {
+		return c.next.SearchPeople(ctx, query)
+	}, "SearchPeople", query)
+}
+
+// GetPerson busca uma pessoa específica pelo ID
+func (c *CachedTVMazeClient) GetPerson(ctx context.Context, id string) (*models.Person, error) {
+	return cached(c, c.config.People, func() (*models.Person, error) {
+		return c.next.GetPerson(ctx, id)
+	}, "GetPerson", id)
+}
+
+// GetPersonCastCredits busca os créditos de elenco de uma pessoa
+func (c *CachedTVMazeClient) GetPersonCastCredits(ctx context.Context, personID string) ([]models.PersonCastCredit, error) {
+	return cached(c, c.config.People, func() ([]models.PersonCastCredit, error) {
+		return c.next.GetPersonCastCredits(ctx, personID)
+	}, "GetPersonCastCredits", personID)
+}
+
+// GetPersonCrewCredits busca os créditos de equipe técnica de uma pessoa
+func (c *CachedTVMazeClient) GetPersonCrewCredits(ctx context.Context, personID string) ([]models.PersonCrewCredit, error) {
+	return cached(c, c.config.People, func() ([]models.PersonCrewCredit, error) {
+		return c.next.GetPersonCrewCredits(ctx, personID)
+	}, "GetPersonCrewCredits", personID)
+}
@@ -0,0 +1,73 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github-api-demo/internal/cassette"
+)
+
+var update = flag.Bool("update", false, "regrava as cassetes em testdata acessando as APIs reais")
+
+// newCassette cria o transporte de gravação/reprodução para testdata/<name>.json.
+// Com -update, grava as respostas reais e salva a cassete ao final do teste.
+func newCassette(t *testing.T, name string) *cassette.Recorder {
+	t.Helper()
+	mode := cassette.Replay
+	if *update {
+		mode = cassette.Record
+	}
+	rec, err := cassette.New(filepath.Join("testdata", name+".json"), mode, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := rec.Save(); err != nil {
+			t.Errorf("erro ao salvar cassete: %v", err)
+		}
+	})
+	return rec
+}
+
+func TestGetGitHubUser(t *testing.T) {
+	client := NewGitHubClient(WithTransport(newCassette(t, "github_user")))
+
+	user, err := client.GetUser(context.Background(), "torvalds")
+	if err != nil {
+		t.Fatalf("Erro ao buscar usuário válido: %v", err)
+	}
+	if user.Login != "torvalds" {
+		t.Errorf("Login incorreto: got %v want torvalds", user.Login)
+	}
+	if user.Name == "" {
+		t.Error("Name não deveria estar vazio")
+	}
+
+	_, err = client.GetUser(context.Background(), "usuarioquenaoexiste123456789")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Deveria retornar não encontrado para usuário inexistente, obtido %v", err)
+	}
+}
+
+func TestGetShowAndSearch(t *testing.T) {
+	client := NewTVMazeClient(WithTransport(newCassette(t, "tvmaze_show")))
+
+	show, err := client.GetShowByID(context.Background(), "431")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if show.Name != "Friends" || show.Network == nil || show.Network.Country.Timezone != "America/New_York" {
+		t.Errorf("show incorreto: %+v", show)
+	}
+
+	results, err := client.SearchShows(context.Background(), "friends")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(results) == 0 || results[0].Show.ID != 431 {
+		t.Errorf("busca incorreta: %+v", results)
+	}
+}
@@ -0,0 +1,81 @@
+package clients
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Categorias de erro das APIs externas, comparáveis com errors.Is
+var (
+	ErrInvalidInput        = errors.New("entrada inválida")
+	ErrNotFound            = errors.New("recurso não encontrado")
+	ErrRateLimited         = errors.New("limite de requisições excedido")
+	ErrUpstreamUnavailable = errors.New("API externa indisponível")
+	ErrUpstream            = errors.New("resposta inválida da API externa")
+)
+
+// Error é um erro com categoria (Kind), mensagem para o usuário e, opcionalmente,
+// a causa original e a espera sugerida antes de tentar novamente
+type Error struct {
+	Kind       error
+	Message    string
+	RetryAfter time.Duration
+	Err        error
+}
+
+// NewError cria um erro da categoria informada
+func NewError(kind error, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Is permite errors.Is(err, ErrNotFound) e afins
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is faz o circuito aberto ser tratado como API indisponível
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrUpstreamUnavailable
+}
+
+// RetryAfterSeconds retorna a espera sugerida, em segundos inteiros, por um
+// erro de limite de requisições ou de circuito aberto
+func RetryAfterSeconds(err error) (int, bool) {
+	var open *CircuitOpenError
+	if errors.As(err, &open) {
+		return open.RetryAfterSeconds(), true
+	}
+	var e *Error
+	if errors.As(err, &e) && e.RetryAfter > 0 {
+		return retryAfterSeconds(e.RetryAfter), true
+	}
+	return 0, false
+}
+
+// statusError converte uma resposta HTTP sem sucesso em um erro tipado
+func statusError(resp *http.Response, notFound string) error {
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return NewError(ErrNotFound, notFound)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		e := NewError(ErrRateLimited, "limite de requisições excedido (status code: 429)")
+		e.RetryAfter, _ = retryAfter(resp)
+		return e
+	case resp.StatusCode >= 500:
+		return NewError(ErrUpstreamUnavailable, "status code: "+strconv.Itoa(resp.StatusCode))
+	}
+	return NewError(ErrUpstream, "status code: "+strconv.Itoa(resp.StatusCode))
+}
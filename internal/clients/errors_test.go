@@ -0,0 +1,63 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestErrors_ClassifiesUpstreamStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		kind   error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusServiceUnavailable, ErrUpstreamUnavailable},
+		{http.StatusForbidden, ErrUpstream},
+	}
+
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(tt.status)
+		}))
+
+		var waits []time.Duration
+		client := NewGitHubClient(WithBaseURL(srv.URL))
+		policy := testRetryPolicy(&waits)
+		policy.MaxAttempts = 1
+		client.SetRetryPolicy(policy)
+
+		before := upstreamRequests.Value("github", "GetUser", errorKind(NewError(tt.kind, "")))
+		_, err := client.GetUser(context.Background(), "octocat")
+		srv.Close()
+
+		if got := upstreamRequests.Value("github", "GetUser", errorKind(err)) - before; got != 1 {
+			t.Errorf("status %d: métrica de resultado não incrementada (%v)", tt.status, got)
+		}
+
+		if !errors.Is(err, tt.kind) {
+			t.Errorf("status %d: esperado %v, obtido %v", tt.status, tt.kind, err)
+		}
+		if tt.status == http.StatusNotFound && err.Error() != "usuário não encontrado" {
+			t.Errorf("mensagem inesperada: %q", err.Error())
+		}
+		if seconds, ok := RetryAfterSeconds(err); tt.status == http.StatusTooManyRequests && (!ok || seconds != 7) {
+			t.Errorf("esperado Retry-After de 7s, obtido %d (%v)", seconds, ok)
+		}
+	}
+}
+
+func TestErrors_CircuitOpenIsUnavailable(t *testing.T) {
+	err := error(&CircuitOpenError{Upstream: "tvmaze", RetryAfter: 1500 * time.Millisecond})
+	if !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Error("circuito aberto deveria ser tratado como API indisponível")
+	}
+	if seconds, ok := RetryAfterSeconds(err); !ok || seconds != 2 {
+		t.Errorf("esperado Retry-After de 2s, obtido %d (%v)", seconds, ok)
+	}
+}
@@ -1,6 +1,7 @@
 package clients
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,58 +9,101 @@ import (
 	"time"
 
 	"github-api-demo/internal/models"
+	"github-api-demo/internal/requestid"
 )
 
+// GitHubAPI define as operações disponíveis na API do GitHub.
+// É implementada pelo GitHubClient.
+type GitHubAPI interface {
+	GetUser(ctx context.Context, username string) (*models.GitHubUser, error)
+}
+
 // GitHubClient é o cliente para a API do GitHub
 type GitHubClient struct {
 	httpClient *http.Client
 	baseURL    string
+	userAgent  string
+	retry      RetryPolicy
+	breaker    *circuitBreaker
 }
 
-// NewGitHubClient cria uma nova instância do cliente GitHub
-func NewGitHubClient() *GitHubClient {
+// NewGitHubClient cria uma nova instância do cliente GitHub.
+// Sem opções, usa https://api.github.com com timeout de 10s.
+func NewGitHubClient(opts ...Option) *GitHubClient {
+	o := newClientOptions("https://api.github.com", 10*time.Second, opts)
 	return &GitHubClient{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		baseURL: "https://api.github.com",
+		httpClient: o.httpClient,
+		baseURL:    o.baseURL,
+		userAgent:  o.userAgent,
+		retry:      DefaultRetryPolicy(),
+		breaker:    newCircuitBreaker("github", DefaultBreakerConfig()),
 	}
 }
 
+// SetRetryPolicy altera a política de novas tentativas do cliente
+func (c *GitHubClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
+// SetBreakerConfig altera a configuração do circuit breaker do cliente
+func (c *GitHubClient) SetBreakerConfig(config BreakerConfig) {
+	c.breaker = newCircuitBreaker("github", config)
+}
+
+// BreakerStats retorna o estado do circuit breaker da API do GitHub
+func (c *GitHubClient) BreakerStats() BreakerStats {
+	return c.breaker.Stats()
+}
+
 // GetUser busca dados de um usuário do GitHub
-func (c *GitHubClient) GetUser(username string) (*models.GitHubUser, error) {
+func (c *GitHubClient) GetUser(ctx context.Context, username string) (*models.GitHubUser, error) {
+	start := time.Now()
+	user, err := c.getUser(ctx, username)
+	observeUpstream("github", "GetUser", start, err)
+	return user, err
+}
+
+// getUser faz a requisição de GetUser
+func (c *GitHubClient) getUser(ctx context.Context, username string) (*models.GitHubUser, error) {
 	url := fmt.Sprintf("%s/users/%s", c.baseURL, username)
 	
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
 	}
 	
-	req.Header.Set("User-Agent", "GoLang-TVMaze-API")
+	req.Header.Set("User-Agent", c.userAgent)
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	
-	resp, err := c.httpClient.Do(req)
+	generation, err := c.breaker.allow()
 	if err != nil {
-		return nil, fmt.Errorf("erro ao fazer requisição: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 	
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("usuário não encontrado")
+	start := time.Now()
+	resp, err := c.retry.do(c.httpClient, req)
+	c.breaker.done(ctx, generation, isHealthy(resp, err))
+	logUpstreamCall(ctx, "github", req.URL.Path, start, resp, err)
+	if err != nil {
+		return nil, &Error{Kind: ErrUpstreamUnavailable, Message: "erro ao fazer requisição", Err: err}
 	}
+	defer resp.Body.Close()
 	
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
+		return nil, statusError(resp, "usuário não encontrado")
 	}
 	
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao ler resposta: %w", err)
+		return nil, &Error{Kind: ErrUpstreamUnavailable, Message: "erro ao ler resposta", Err: err}
 	}
 	
 	var user models.GitHubUser
 	if err := json.Unmarshal(body, &user); err != nil {
-		return nil, fmt.Errorf("erro ao decodificar JSON: %w", err)
+		return nil, &Error{Kind: ErrUpstream, Message: "erro ao decodificar JSON", Err: err}
 	}
 	
 	return &user, nil
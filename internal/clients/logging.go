@@ -0,0 +1,31 @@
+package clients
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github-api-demo/internal/logging"
+)
+
+// logUpstreamCall registra uma chamada a uma API externa com os campos da
+// requisição que a originou (request_id, rota...)
+func logUpstreamCall(ctx context.Context, upstream, path string, start time.Time, resp *http.Response, err error) {
+	logger := logging.FromContext(ctx)
+	attrs := []any{
+		"upstream", upstream,
+		"upstream_path", path,
+		"duration_ms", time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		logger.Warn("falha na chamada à API externa", append(attrs, "error", err)...)
+		return
+	}
+
+	level := slog.LevelDebug
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		level = slog.LevelWarn
+	}
+	logger.Log(ctx, level, "chamada à API externa", append(attrs, "status", resp.StatusCode)...)
+}
@@ -0,0 +1,61 @@
+package clients
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github-api-demo/internal/metrics"
+)
+
+// Métricas das chamadas às APIs externas e do cache, expostas em /metrics
+var (
+	upstreamRequests = metrics.NewCounterVec("upstream_requests_total",
+		"Chamadas às APIs externas por método do cliente e resultado", "upstream", "method", "result")
+	upstreamDuration = metrics.NewHistogramVec("upstream_request_duration_seconds",
+		"Duração das chamadas às APIs externas, incluindo novas tentativas", nil, "upstream", "method")
+	cacheLookups = metrics.NewCounterVec("cache_lookups_total",
+		"Consultas ao cache do TVMaze por método e resultado (hit ou miss)", "cache", "method", "result")
+
+	// cacheTotals acumula acertos e falhas de todos os caches para a taxa de acerto
+	cacheTotals CacheRecorder
+
+	_ = metrics.NewGaugeFunc("cache_hit_ratio",
+		"Fração das consultas ao cache do TVMaze atendidas sem chamar a API", func() []metrics.Sample {
+			hits, misses := atomic.LoadInt64(&cacheTotals.hits), atomic.LoadInt64(&cacheTotals.misses)
+			if hits+misses == 0 {
+				return nil
+			}
+			return []metrics.Sample{{Labels: []string{"tvmaze"}, Value: float64(hits) / float64(hits+misses)}}
+		}, "cache")
+)
+
+// observeUpstream registra o resultado e a duração de uma chamada a uma API externa.
+// Chamadas recusadas pelo circuito aberto contam no resultado, mas não na latência.
+func observeUpstream(upstream, method string, start time.Time, err error) {
+	result := errorKind(err)
+	upstreamRequests.Inc(upstream, method, result)
+	if result != "circuit_open" {
+		upstreamDuration.Observe(time.Since(start).Seconds(), upstream, method)
+	}
+}
+
+// errorKind classifica o erro de uma chamada para os rótulos das métricas
+func errorKind(err error) string {
+	var open *CircuitOpenError
+	switch {
+	case err == nil:
+		return "success"
+	case errors.As(err, &open):
+		return "circuit_open"
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrUpstreamUnavailable):
+		return "unavailable"
+	case errors.Is(err, ErrUpstream):
+		return "upstream_error"
+	}
+	return "error"
+}
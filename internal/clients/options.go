@@ -0,0 +1,83 @@
+package clients
+
+import (
+	"net/http"
+	"time"
+)
+
+// defaultUserAgent é o User-Agent enviado às APIs externas quando nenhum é configurado
+const defaultUserAgent = "GoLang-TVMaze-API"
+
+// Option configura um cliente das APIs externas (TVMazeClient ou GitHubClient)
+type Option func(*clientOptions)
+
+// clientOptions reúne as configurações aplicadas pelas Options
+type clientOptions struct {
+	baseURL    string
+	httpClient *http.Client
+	timeout    time.Duration
+	userAgent  string
+	transport  http.RoundTripper
+}
+
+// WithBaseURL altera a URL base da API (ex: um servidor mock local)
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient usa o http.Client informado. WithTimeout e WithTransport,
+// quando presentes, são aplicados sobre uma cópia dele.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = client
+	}
+}
+
+// WithTimeout altera o timeout total de cada requisição HTTP
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithUserAgent altera o header User-Agent enviado à API
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithTransport altera o http.RoundTripper usado nas requisições
+// (proxy, pool de conexões, gravação de respostas...)
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
+// newClientOptions aplica as opções sobre os valores padrão de cada cliente
+func newClientOptions(baseURL string, timeout time.Duration, opts []Option) clientOptions {
+	o := clientOptions{userAgent: defaultUserAgent}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.baseURL == "" {
+		o.baseURL = baseURL
+	}
+
+	client := &http.Client{Timeout: timeout}
+	if o.httpClient != nil {
+		copied := *o.httpClient
+		client = &copied
+	}
+	if o.timeout > 0 {
+		client.Timeout = o.timeout
+	}
+	if o.transport != nil {
+		client.Transport = o.transport
+	}
+	o.httpClient = client
+	return o
+}
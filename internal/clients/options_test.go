@@ -0,0 +1,70 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github-api-demo/internal/requestid"
+)
+
+// roundTripFunc adapta uma função para http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestOptions_Defaults(t *testing.T) {
+	client := NewTVMazeClient()
+	if client.baseURL != "https://api.tvmaze.com" || client.httpClient.Timeout != 15*time.Second {
+		t.Errorf("padrões incorretos: %s %v", client.baseURL, client.httpClient.Timeout)
+	}
+	if client.userAgent != defaultUserAgent {
+		t.Errorf("User-Agent padrão incorreto: %q", client.userAgent)
+	}
+}
+
+func TestOptions_BaseURLAndUserAgent(t *testing.T) {
+	var userAgent, requestID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		requestID = r.Header.Get(requestid.Header)
+		w.Write([]byte(`{"login": "octocat"}`))
+	}))
+	defer srv.Close()
+
+	client := NewGitHubClient(WithBaseURL(srv.URL), WithUserAgent("teste/1.0"))
+	ctx := requestid.WithID(context.Background(), "req-123")
+	if _, err := client.GetUser(ctx, "octocat"); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if userAgent != "teste/1.0" {
+		t.Errorf("User-Agent não aplicado: %q", userAgent)
+	}
+	if requestID != "req-123" {
+		t.Errorf("X-Request-ID não repassado: %q", requestID)
+	}
+}
+
+func TestOptions_TransportAndTimeoutOverrideHTTPClient(t *testing.T) {
+	base := &http.Client{Timeout: time.Minute}
+	var called bool
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		called = true
+		return httptest.NewRecorder().Result(), nil
+	})
+
+	client := NewTVMazeClient(WithHTTPClient(base), WithTransport(transport), WithTimeout(2*time.Second))
+	if client.httpClient == base {
+		t.Error("o http.Client informado não deve ser alterado")
+	}
+	if client.httpClient.Timeout != 2*time.Second || base.Timeout != time.Minute {
+		t.Errorf("timeout incorreto: %v (original %v)", client.httpClient.Timeout, base.Timeout)
+	}
+	if _, err := client.GetShowByID(context.Background(), "1"); err == nil || !called {
+		t.Errorf("transport não foi usado: %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github-api-demo/internal/logging"
+	"github-api-demo/internal/tracing"
+)
+
+// RetryPolicy define como as requisições às APIs externas são repetidas em falhas
+// transitórias: HTTP 429, erros 5xx e conexões interrompidas
+type RetryPolicy struct {
+	MaxAttempts int           // total de tentativas, incluindo a primeira
+	BaseDelay   time.Duration // espera antes da segunda tentativa, dobrada a cada nova tentativa
+	MaxDelay    time.Duration // limite para a espera entre tentativas
+
+	sleep func(time.Duration)
+}
+
+// DefaultRetryPolicy retorna a política padrão: 3 tentativas com backoff exponencial
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// do executa a requisição, repetindo-a conforme a política.
+// Em caso de desistência, retorna a última resposta ou erro obtido.
+// Cada tentativa pode usar todo o tempo restante do contexto da requisição, para
+// que uma resposta lenta não falhe por um prazo menor que o da própria requisição.
+// Não inicia uma nova tentativa se a espera ultrapassar esse prazo.
+func (p RetryPolicy) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		resp, err := p.send(ctx, client, req, attempt)
+
+		if attempt >= p.MaxAttempts || ctx.Err() != nil || !isRetryable(resp, err) {
+			return resp, err
+		}
+
+		delay := p.backoff(attempt)
+		if resp != nil {
+			if wait, ok := retryAfter(resp); ok {
+				delay = wait
+			}
+		}
+
+		// Se a API pede para esperar mais do que aceitamos, ou se não há tempo
+		// restante para esperar e tentar de novo, desistimos já
+		if delay > p.MaxDelay || !fitsDeadline(ctx, delay) {
+			return resp, err
+		}
+
+		logging.FromContext(ctx).Warn("nova tentativa na API externa",
+			"url", req.URL.Redacted(),
+			"attempt", attempt+1,
+			"delay_ms", delay.Milliseconds(),
+			"reason", retryReason(resp, err),
+		)
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := p.wait(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send faz uma tentativa dentro de um span de cliente, propagando o traceparent
+// para a API externa
+func (p RetryPolicy) send(ctx context.Context, client *http.Client, req *http.Request, attempt int) (*http.Response, error) {
+	ctx, span := tracing.Start(ctx, req.Method, tracing.KindClient)
+	defer span.End()
+	span.SetAttributes(
+		"http.request.method", req.Method,
+		"url.full", req.URL.Redacted(),
+		"server.address", req.URL.Hostname(),
+	)
+	if attempt > 1 {
+		span.SetAttributes("http.request.resend_count", attempt-1)
+	}
+
+	attemptReq := req.Clone(ctx)
+	tracing.Inject(ctx, attemptReq.Header)
+
+	resp, err := client.Do(attemptReq)
+	if err != nil {
+		span.RecordError(err)
+		return resp, err
+	}
+	span.SetAttributes("http.response.status_code", resp.StatusCode)
+	if resp.StatusCode >= 400 {
+		span.SetStatus(tracing.StatusError, resp.Status)
+	}
+	return resp, nil
+}
+
+// fitsDeadline indica se ainda há tempo para esperar delay antes do prazo do contexto
+func fitsDeadline(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > delay
+}
+
+// backoff calcula a espera antes da próxima tentativa (exponencial com jitter)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// Jitter: espera entre metade e o total do backoff calculado
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// wait aguarda a duração informada ou até o contexto ser cancelado
+func (p RetryPolicy) wait(ctx context.Context, d time.Duration) error {
+	if p.sleep != nil {
+		p.sleep(d)
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isRetryable indica se a falha é transitória e a requisição (GET) pode ser repetida
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			errors.Is(err, io.EOF)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// retryAfter interpreta o header Retry-After (segundos ou data HTTP)
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(v); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// isHealthy indica se a resposta mostra a API externa funcionando; respostas
+// como 404 são erros do cliente e não contam como falha da API
+func isHealthy(resp *http.Response, err error) bool {
+	return err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500
+}
+
+// retryReason descreve a falha que motivou uma nova tentativa, para o log
+func retryReason(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.Status
+}
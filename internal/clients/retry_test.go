@@ -0,0 +1,178 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github-api-demo/internal/tracing"
+)
+
+// testRetryPolicy não espera entre tentativas e registra as esperas pedidas
+func testRetryPolicy(waits *[]time.Duration) RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.sleep = func(d time.Duration) { *waits = append(*waits, d) }
+	return policy
+}
+
+func TestRetry_RecoversFromServerError(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 431, "name": "Friends"}`))
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := NewTVMazeClient(WithBaseURL(srv.URL))
+	client.SetRetryPolicy(testRetryPolicy(&waits))
+
+	show, err := client.GetShowByID(context.Background(), "431")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if show.Name != "Friends" {
+		t.Errorf("show incorreto: %+v", show)
+	}
+	if calls != 3 {
+		t.Errorf("esperado 3 tentativas, obtido %d", calls)
+	}
+	if len(waits) != 2 || waits[1] < waits[0]/2 {
+		t.Errorf("esperas inesperadas entre tentativas: %v", waits)
+	}
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"login": "octocat"}`))
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := NewGitHubClient(WithBaseURL(srv.URL))
+	client.SetRetryPolicy(testRetryPolicy(&waits))
+
+	if _, err := client.GetUser(context.Background(), "octocat"); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(waits) != 1 || waits[0] != 2*time.Second {
+		t.Errorf("esperado aguardar 2s conforme Retry-After, obtido %v", waits)
+	}
+}
+
+func TestRetry_GivesUpOnLongRetryAfter(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := NewTVMazeClient(WithBaseURL(srv.URL))
+	client.SetRetryPolicy(testRetryPolicy(&waits))
+
+	_, err := client.GetShowByID(context.Background(), "431")
+	if err == nil || err.Error() != "limite de requisições excedido (status code: 429)" {
+		t.Errorf("erro inesperado: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("não deveria repetir quando Retry-After excede o limite, obtido %d tentativas", calls)
+	}
+}
+
+func TestRetry_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := NewTVMazeClient(WithBaseURL(srv.URL))
+	client.SetRetryPolicy(testRetryPolicy(&waits))
+
+	if _, err := client.GetShowByID(context.Background(), "0"); err == nil {
+		t.Error("esperado erro para 404")
+	}
+	if calls != 1 {
+		t.Errorf("404 não deve ser repetido, obtido %d tentativas", calls)
+	}
+}
+
+func TestRetry_SlowResponseUsesWholeBudget(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(1200 * time.Millisecond)
+		w.Write([]byte(`{"id": 431, "name": "Friends"}`))
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := NewTVMazeClient(WithBaseURL(srv.URL))
+	client.SetRetryPolicy(testRetryPolicy(&waits))
+
+	// Dividido entre 3 tentativas, o orçamento daria menos que a latência do servidor
+	ctx, cancel := context.WithTimeout(context.Background(), 3500*time.Millisecond)
+	defer cancel()
+
+	show, err := client.GetShowByID(ctx, "431")
+	if err != nil {
+		t.Fatalf("resposta lenta dentro do orçamento deveria ter sucesso: %v", err)
+	}
+	if show.Name != "Friends" || calls != 1 {
+		t.Errorf("esperado 1 tentativa com sucesso, obtido %d: %+v", calls, show)
+	}
+}
+
+func TestRetry_PropagatesTraceparent(t *testing.T) {
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(tracing.TraceparentHeader))
+		if len(seen) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 431, "name": "Friends"}`))
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := NewTVMazeClient(WithBaseURL(srv.URL))
+	client.SetRetryPolicy(testRetryPolicy(&waits))
+
+	ctx, span := tracing.Start(context.Background(), "teste", tracing.KindInternal)
+	defer span.End()
+	if _, err := client.GetShowByID(ctx, "431"); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if len(seen) != 2 {
+		t.Fatalf("esperado 2 tentativas, obtido %d", len(seen))
+	}
+	parent := span.SpanContext()
+	for i, v := range seen {
+		sc, ok := tracing.ParseTraceparent(v)
+		if !ok || sc.TraceID != parent.TraceID || sc.SpanID == parent.SpanID {
+			t.Errorf("tentativa %d: traceparent incorreto %q", i+1, v)
+		}
+	}
+	if seen[0] == seen[1] {
+		t.Error("cada tentativa deveria ter o próprio span")
+	}
+}
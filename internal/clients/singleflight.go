@@ -0,0 +1,127 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// CoalescingStats resume a deduplicação de chamadas concorrentes à API
+type CoalescingStats struct {
+	Requests  int64 `json:"requests"`
+	Coalesced int64 `json:"coalesced"`
+	InFlight  int   `json:"in_flight"`
+}
+
+// flightGroup garante que chamadas concorrentes com a mesma chave compartilhem
+// uma única execução em andamento (no estilo singleflight)
+type flightGroup struct {
+	mu        sync.Mutex
+	calls     map[string]*flightCall
+	requests  int64
+	coalesced int64
+}
+
+type flightCall struct {
+	done    chan struct{}
+	val     []byte
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall)}
+}
+
+// Do executa fn para a chave, ou aguarda a execução já em andamento e devolve
+// o mesmo resultado. shared indica se o resultado foi compartilhado.
+//
+// A execução compartilhada não é cancelada quando um dos chamadores desiste,
+// apenas quando todos desistem. Ela herda o prazo de quem a iniciou.
+func (g *flightGroup) Do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) (val []byte, err error, shared bool) {
+	atomic.AddInt64(&g.requests, 1)
+
+	g.mu.Lock()
+	call, shared := g.calls[key]
+	if shared {
+		atomic.AddInt64(&g.coalesced, 1)
+	} else {
+		call = g.start(ctx, key, fn)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.val, call.err, shared
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Quem chegar depois inicia uma nova execução em vez de aguardar esta, já cancelada
+			call.cancel()
+			g.forget(key, call)
+		}
+		g.mu.Unlock()
+		return nil, waitError(ctx.Err()), shared
+	}
+}
+
+// waitError converte o fim do contexto de quem aguardava a execução em um erro
+// tipado, o mesmo que a execução daria se terminasse primeiro pelo mesmo motivo
+func waitError(err error) error {
+	message := "tempo esgotado aguardando a API externa"
+	if errors.Is(err, context.Canceled) {
+		message = "requisição cancelada aguardando a API externa"
+	}
+	return &Error{Kind: ErrUpstreamUnavailable, Message: message, Err: err}
+}
+
+// start inicia a execução compartilhada; deve ser chamado com g.mu travado
+func (g *flightGroup) start(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) *flightCall {
+	var fctx context.Context
+	var cancel context.CancelFunc
+	if deadline, ok := ctx.Deadline(); ok {
+		fctx, cancel = context.WithDeadline(context.WithoutCancel(ctx), deadline)
+	} else {
+		fctx, cancel = context.WithCancel(context.WithoutCancel(ctx))
+	}
+
+	call := &flightCall{done: make(chan struct{}), cancel: cancel}
+	g.calls[key] = call
+
+	go func() {
+		call.val, call.err = fn(fctx)
+		cancel()
+
+		g.mu.Lock()
+		g.forget(key, call)
+		g.mu.Unlock()
+
+		close(call.done)
+	}()
+
+	return call
+}
+
+// forget remove a execução da chave, se ainda for a atual; deve ser chamado com g.mu travado
+func (g *flightGroup) forget(key string, call *flightCall) {
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
+
+// Stats retorna os contadores de deduplicação
+func (g *flightGroup) Stats() CoalescingStats {
+	g.mu.Lock()
+	inFlight := len(g.calls)
+	g.mu.Unlock()
+
+	return CoalescingStats{
+		Requests:  atomic.LoadInt64(&g.requests),
+		Coalesced: atomic.LoadInt64(&g.coalesced),
+		InFlight:  inFlight,
+	}
+}
@@ -0,0 +1,168 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFlightGroup_CoalescesConcurrentCalls(t *testing.T) {
+	group := newFlightGroup()
+
+	var mu sync.Mutex
+	executions := 0
+	release := make(chan struct{})
+
+	fn := func(ctx context.Context) ([]byte, error) {
+		mu.Lock()
+		executions++
+		mu.Unlock()
+		<-release
+		return []byte("ok"), nil
+	}
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([]string, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			val, _, _ := group.Do(context.Background(), "GET /schedule", fn)
+			results[i] = string(val)
+		}(i)
+	}
+
+	// Aguarda todos os chamadores chegarem antes de liberar a execução
+	for group.Stats().Requests < callers {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if executions != 1 {
+		t.Errorf("esperado 1 execução, obtido %d", executions)
+	}
+	for i, r := range results {
+		if r != "ok" {
+			t.Errorf("chamador %d recebeu %q", i, r)
+		}
+	}
+
+	stats := group.Stats()
+	if stats.Coalesced != callers-1 {
+		t.Errorf("esperado %d chamadas deduplicadas, obtido %d", callers-1, stats.Coalesced)
+	}
+	if stats.InFlight != 0 {
+		t.Errorf("nenhuma chamada deveria estar em andamento, obtido %d", stats.InFlight)
+	}
+}
+
+func TestFlightGroup_CancelsOnlyWhenAllCallersLeave(t *testing.T) {
+	group := newFlightGroup()
+
+	started := make(chan struct{})
+	canceled := make(chan struct{})
+	fn := func(ctx context.Context) ([]byte, error) {
+		close(started)
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	}
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	secondCtx, cancelSecond := context.WithCancel(context.Background())
+
+	errs := make(chan error, 2)
+	go func() {
+		_, err, _ := group.Do(firstCtx, "GET /shows/1", fn)
+		errs <- err
+	}()
+	<-started
+	go func() {
+		_, err, _ := group.Do(secondCtx, "GET /shows/1", fn)
+		errs <- err
+	}()
+	for group.Stats().Coalesced < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	cancelFirst()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("primeiro chamador deveria receber context.Canceled, obtido %v", err)
+	}
+
+	select {
+	case <-canceled:
+		t.Fatal("a execução compartilhada não deve ser cancelada enquanto houver chamadores")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancelSecond()
+	<-errs
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("a execução compartilhada deveria ser cancelada quando todos desistem")
+	}
+}
+
+func TestFlightGroup_AbandonedCallIsNotJoined(t *testing.T) {
+	group := newFlightGroup()
+
+	release := make(chan struct{})
+	abandoned := func(ctx context.Context) ([]byte, error) {
+		<-release
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		group.Do(ctx, "GET /shows/1", abandoned)
+		close(done)
+	}()
+	for group.Stats().InFlight < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+	defer close(release)
+
+	// A execução cancelada ainda não terminou, mas um novo chamador não deve aguardá-la
+	val, err, shared := group.Do(context.Background(), "GET /shows/1", func(ctx context.Context) ([]byte, error) {
+		return []byte("ok"), nil
+	})
+	if err != nil || string(val) != "ok" || shared {
+		t.Errorf("esperada nova execução, obtido val=%q err=%v shared=%v", val, err, shared)
+	}
+}
+
+func TestFlightGroup_CallerDeadlineIsUpstreamUnavailable(t *testing.T) {
+	group := newFlightGroup()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	// A execução ganha o próprio prazo, maior que o de quem chega depois
+	go group.Do(context.Background(), "GET /shows/1", func(ctx context.Context) ([]byte, error) {
+		<-release
+		return []byte("ok"), nil
+	})
+	for group.Stats().InFlight < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err, shared := group.Do(ctx, "GET /shows/1", func(ctx context.Context) ([]byte, error) {
+		t.Error("a execução em andamento deveria ser compartilhada")
+		return nil, nil
+	})
+
+	if !shared || !errors.Is(err, ErrUpstreamUnavailable) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("prazo esgotado deveria ser ErrUpstreamUnavailable, obtido %v (shared=%v)", err, shared)
+	}
+}
@@ -1,129 +1,306 @@
 package clients
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github-api-demo/internal/models"
+	"github-api-demo/internal/requestid"
 )
 
+// TVMazeAPI define as operações disponíveis na API do TVMaze.
+// É implementada pelo TVMazeClient e pelos decoradores (ex: CachedTVMazeClient).
+type TVMazeAPI interface {
+	GetSchedule(ctx context.Context, country, date string) ([]models.Schedule, error)
+	GetWebSchedule(ctx context.Context, country, date string) ([]models.Schedule, error)
+	SearchShows(ctx context.Context, query string) ([]models.SearchResult, error)
+	GetShowByID(ctx context.Context, id string) (*models.Show, error)
+	LookupShow(ctx context.Context, source, id string) (*models.Show, error)
+	GetEpisodes(ctx context.Context, showID string) ([]models.Episode, error)
+	GetSeasons(ctx context.Context, showID string) ([]models.Season, error)
+	GetSeasonEpisodes(ctx context.Context, seasonID string) ([]models.Episode, error)
+	GetCast(ctx context.Context, showID string) ([]models.CastCredit, error)
+	GetCrew(ctx context.Context, showID string) ([]models.CrewCredit, error)
+	SearchPeople(ctx context.Context, query string) ([]models.PersonSearchResult, error)
+	GetPerson(ctx context.Context, id string) (*models.Person, error)
+	GetPersonCastCredits(ctx context.Context, personID string) ([]models.PersonCastCredit, error)
+	GetPersonCrewCredits(ctx context.Context, personID string) ([]models.PersonCrewCredit, error)
+}
+
 // TVMazeClient é o cliente para a API do TVMaze
 type TVMazeClient struct {
 	httpClient *http.Client
 	baseURL    string
+	userAgent  string
+	flights    *flightGroup
+	retry      RetryPolicy
+	breaker    *circuitBreaker
 }
 
-// NewTVMazeClient cria uma nova instância do cliente TVMaze
-func NewTVMazeClient() *TVMazeClient {
+// NewTVMazeClient cria uma nova instância do cliente TVMaze.
+// Sem opções, usa https://api.tvmaze.com com timeout de 15s.
+func NewTVMazeClient(opts ...Option) *TVMazeClient {
+	o := newClientOptions("https://api.tvmaze.com", 15*time.Second, opts)
 	return &TVMazeClient{
-		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
-		},
-		baseURL: "https://api.tvmaze.com",
+		httpClient: o.httpClient,
+		baseURL:    o.baseURL,
+		userAgent:  o.userAgent,
+		flights:    newFlightGroup(),
+		retry:      DefaultRetryPolicy(),
+		breaker:    newCircuitBreaker("tvmaze", DefaultBreakerConfig()),
 	}
 }
 
+// SetRetryPolicy altera a política de novas tentativas do cliente
+func (c *TVMazeClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
 // GetSchedule busca a programação de um país e data
-func (c *TVMazeClient) GetSchedule(country, date string) ([]models.Schedule, error) {
-	url := fmt.Sprintf("%s/schedule?country=%s&date=%s", c.baseURL, country, date)
-	
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
-	}
-	
-	req.Header.Set("User-Agent", "GoLang-TVMaze-API")
-	
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao fazer requisição: %w", err)
+func (c *TVMazeClient) GetSchedule(ctx context.Context, country, date string) ([]models.Schedule, error) {
+	var schedule []models.Schedule
+	if err := c.get(ctx, "GetSchedule", fmt.Sprintf("/schedule?country=%s&date=%s", url.QueryEscape(country), url.QueryEscape(date)), &schedule); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
+	return schedule, nil
+}
+
+// GetWebSchedule busca a programação de streaming (web) de um país e data.
+// Nesse endpoint a TVMaze devolve o show dentro de _embedded.
+func (c *TVMazeClient) GetWebSchedule(ctx context.Context, country, date string) ([]models.Schedule, error) {
+	var raw []struct {
+		models.Schedule
+		Embedded struct {
+			Show models.Show `json:"show"`
+		} `json:"_embedded"`
 	}
-	
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao ler resposta: %w", err)
+	if err := c.get(ctx, "GetWebSchedule", fmt.Sprintf("/schedule/web?country=%s&date=%s", url.QueryEscape(country), url.QueryEscape(date)), &raw); err != nil {
+		return nil, err
 	}
-	
-	var schedule []models.Schedule
-	if err := json.Unmarshal(body, &schedule); err != nil {
-		return nil, fmt.Errorf("erro ao decodificar JSON: %w", err)
+
+	schedule := make([]models.Schedule, 0, len(raw))
+	for _, item := range raw {
+		if item.Show.ID == 0 {
+			item.Show = item.Embedded.Show
+		}
+		schedule = append(schedule, item.Schedule)
 	}
-	
 	return schedule, nil
 }
 
 // SearchShows busca shows pelo nome
-func (c *TVMazeClient) SearchShows(query string) ([]map[string]interface{}, error) {
-	url := fmt.Sprintf("%s/search/shows?q=%s", c.baseURL, query)
-	
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+func (c *TVMazeClient) SearchShows(ctx context.Context, query string) ([]models.SearchResult, error) {
+	var results []models.SearchResult
+	if err := c.get(ctx, "SearchShows", fmt.Sprintf("/search/shows?q=%s", url.QueryEscape(query)), &results); err != nil {
+		return nil, err
 	}
-	
-	req.Header.Set("User-Agent", "GoLang-TVMaze-API")
-	
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao fazer requisição: %w", err)
+	return results, nil
+}
+
+// GetShowByID busca um show específico pelo ID
+func (c *TVMazeClient) GetShowByID(ctx context.Context, id string) (*models.Show, error) {
+	var show models.Show
+	if err := c.get(ctx, "GetShowByID", fmt.Sprintf("/shows/%s", id), &show); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
+	return &show, nil
+}
+
+// LookupShow busca um show pelo ID em uma base externa (imdb, thetvdb ou tvrage).
+// A TVMaze responde com um redirecionamento para /shows/:id, seguido pelo http.Client.
+func (c *TVMazeClient) LookupShow(ctx context.Context, source, id string) (*models.Show, error) {
+	var show models.Show
+	if err := c.get(ctx, "LookupShow", fmt.Sprintf("/lookup/shows?%s=%s", source, url.QueryEscape(id)), &show); err != nil {
+		return nil, err
 	}
-	
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao ler resposta: %w", err)
+	return &show, nil
+}
+
+// GetEpisodes busca a lista de episódios de um show
+func (c *TVMazeClient) GetEpisodes(ctx context.Context, showID string) ([]models.Episode, error) {
+	var episodes []models.Episode
+	if err := c.get(ctx, "GetEpisodes", fmt.Sprintf("/shows/%s/episodes", showID), &episodes); err != nil {
+		return nil, err
+	}
+	return episodes, nil
+}
+
+// GetSeasons busca as temporadas de um show
+func (c *TVMazeClient) GetSeasons(ctx context.Context, showID string) ([]models.Season, error) {
+	var seasons []models.Season
+	if err := c.get(ctx, "GetSeasons", fmt.Sprintf("/shows/%s/seasons", showID), &seasons); err != nil {
+		return nil, err
+	}
+	return seasons, nil
+}
+
+// GetSeasonEpisodes busca os episódios de uma temporada
+func (c *TVMazeClient) GetSeasonEpisodes(ctx context.Context, seasonID string) ([]models.Episode, error) {
+	var episodes []models.Episode
+	if err := c.get(ctx, "GetSeasonEpisodes", fmt.Sprintf("/seasons/%s/episodes", seasonID), &episodes); err != nil {
+		return nil, err
+	}
+	return episodes, nil
+}
+
+// GetCast busca o elenco de um show
+func (c *TVMazeClient) GetCast(ctx context.Context, showID string) ([]models.CastCredit, error) {
+	var cast []models.CastCredit
+	if err := c.get(ctx, "GetCast", fmt.Sprintf("/shows/%s/cast", showID), &cast); err != nil {
+		return nil, err
 	}
-	
-	var results []map[string]interface{}
-	if err := json.Unmarshal(body, &results); err != nil {
-		return nil, fmt.Errorf("erro ao decodificar JSON: %w", err)
+	return cast, nil
+}
+
+// GetCrew busca a equipe técnica de um show
+func (c *TVMazeClient) GetCrew(ctx context.Context, showID string) ([]models.CrewCredit, error) {
+	var crew []models.CrewCredit
+	if err := c.get(ctx, "GetCrew", fmt.Sprintf("/shows/%s/crew", showID), &crew); err != nil {
+		return nil, err
+	}
+	return crew, nil
+}
+
+// SearchPeople busca pessoas pelo nome
+func (c *TVMazeClient) SearchPeople(ctx context.Context, query string) ([]models.PersonSearchResult, error) {
+	var results []models.PersonSearchResult
+	if err := c.get(ctx, "SearchPeople", fmt.Sprintf("/search/people?q=%s", url.QueryEscape(query)), &results); err != nil {
+		return nil, err
 	}
-	
 	return results, nil
 }
 
-// GetShowByID busca um show específico pelo ID
-func (c *TVMazeClient) GetShowByID(id string) (*models.Show, error) {
-	url := fmt.Sprintf("%s/shows/%s", c.baseURL, id)
-	
-	req, err := http.NewRequest("GET", url, nil)
+// GetPerson busca uma pessoa específica pelo ID
+func (c *TVMazeClient) GetPerson(ctx context.Context, id string) (*models.Person, error) {
+	var person models.Person
+	if err := c.get(ctx, "GetPerson", fmt.Sprintf("/people/%s", id), &person); err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
+
+// GetPersonCastCredits busca os créditos de elenco de uma pessoa com os shows embutidos
+func (c *TVMazeClient) GetPersonCastCredits(ctx context.Context, personID string) ([]models.PersonCastCredit, error) {
+	var raw []struct {
+		Self     bool `json:"self"`
+		Voice    bool `json:"voice"`
+		Embedded struct {
+			Show models.Show `json:"show"`
+		} `json:"_embedded"`
+	}
+	if err := c.get(ctx, "GetPersonCastCredits", fmt.Sprintf("/people/%s/castcredits?embed=show", personID), &raw); err != nil {
+		return nil, err
+	}
+
+	credits := make([]models.PersonCastCredit, 0, len(raw))
+	for _, item := range raw {
+		credits = append(credits, models.PersonCastCredit{
+			Self:  item.Self,
+			Voice: item.Voice,
+			Show:  item.Embedded.Show,
+		})
+	}
+	return credits, nil
+}
+
+// GetPersonCrewCredits busca os créditos de equipe técnica de uma pessoa com os shows embutidos
+func (c *TVMazeClient) GetPersonCrewCredits(ctx context.Context, personID string) ([]models.PersonCrewCredit, error) {
+	var raw []struct {
+		Type     string `json:"type"`
+		Embedded struct {
+			Show models.Show `json:"show"`
+		} `json:"_embedded"`
+	}
+	if err := c.get(ctx, "GetPersonCrewCredits", fmt.Sprintf("/people/%s/crewcredits?embed=show", personID), &raw); err != nil {
+		return nil, err
+	}
+
+	credits := make([]models.PersonCrewCredit, 0, len(raw))
+	for _, item := range raw {
+		credits = append(credits, models.PersonCrewCredit{
+			Type: item.Type,
+			Show: item.Embedded.Show,
+		})
+	}
+	return credits, nil
+}
+
+// SetBreakerConfig altera a configuração do circuit breaker do cliente
+func (c *TVMazeClient) SetBreakerConfig(config BreakerConfig) {
+	c.breaker = newCircuitBreaker("tvmaze", config)
+}
+
+// BreakerStats retorna o estado do circuit breaker da API do TVMaze
+func (c *TVMazeClient) BreakerStats() BreakerStats {
+	return c.breaker.Stats()
+}
+
+// CoalescingStats retorna quantas chamadas foram feitas e quantas foram
+// atendidas por uma requisição idêntica já em andamento
+func (c *TVMazeClient) CoalescingStats() CoalescingStats {
+	return c.flights.Stats()
+}
+
+// get faz uma requisição GET para o caminho informado e decodifica o JSON em out.
+// Chamadas concorrentes para o mesmo caminho compartilham uma única requisição.
+// method é o método do cliente que originou a chamada, usado nas métricas.
+func (c *TVMazeClient) get(ctx context.Context, method, path string, out interface{}) error {
+	body, err, _ := c.flights.Do(ctx, "GET "+path, func(ctx context.Context) ([]byte, error) {
+		start := time.Now()
+		body, err := c.fetch(ctx, path)
+		observeUpstream("tvmaze", method, start, err)
+		return body, err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return &Error{Kind: ErrUpstream, Message: "erro ao decodificar JSON", Err: err}
+	}
+
+	return nil
+}
+
+// fetch faz a requisição GET e retorna o corpo da resposta
+func (c *TVMazeClient) fetch(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
 	}
-	
-	req.Header.Set("User-Agent", "GoLang-TVMaze-API")
-	
-	resp, err := c.httpClient.Do(req)
+
+	req.Header.Set("User-Agent", c.userAgent)
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
+
+	generation, err := c.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := c.retry.do(c.httpClient, req)
+	c.breaker.done(ctx, generation, isHealthy(resp, err))
+	logUpstreamCall(ctx, "tvmaze", path, start, resp, err)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao fazer requisição: %w", err)
+		return nil, &Error{Kind: ErrUpstreamUnavailable, Message: "erro ao fazer requisição", Err: err}
 	}
 	defer resp.Body.Close()
-	
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
+		return nil, statusError(resp, "recurso não encontrado no TVMaze")
 	}
-	
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao ler resposta: %w", err)
-	}
-	
-	var show models.Show
-	if err := json.Unmarshal(body, &show); err != nil {
-		return nil, fmt.Errorf("erro ao decodificar JSON: %w", err)
+		return nil, &Error{Kind: ErrUpstreamUnavailable, Message: "erro ao ler resposta", Err: err}
 	}
-	
-	return &show, nil
+
+	return body, nil
 }
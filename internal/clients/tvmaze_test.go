@@ -0,0 +1,162 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newFixtureTVMaze cria um cliente apontando para um servidor que responde com
+// o JSON cadastrado para cada caminho com query (ex: "/shows/1/cast")
+func newFixtureTVMaze(t *testing.T, responses map[string]string) *TVMazeClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return NewTVMazeClient(WithBaseURL(srv.URL))
+}
+
+func TestGetWebSchedule_MovesEmbeddedShow(t *testing.T) {
+	client := newFixtureTVMaze(t, map[string]string{
+		"/schedule/web?country=US&date=2024-03-01": `[
+			{"id": 2779001, "airdate": "2024-03-01", "airtime": "", "airstamp": "2024-03-01T12:00:00+00:00", "runtime": 30,
+			 "_embedded": {"show": {"id": 65270, "name": "Avatar: The Last Airbender", "type": "Scripted", "webChannel": {"id": 1, "name": "Netflix"}}}}
+		]`,
+	})
+
+	schedule, err := client.GetWebSchedule(context.Background(), "US", "2024-03-01")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(schedule) != 1 {
+		t.Fatalf("esperado 1 item, obtido %d", len(schedule))
+	}
+	if show := schedule[0].Show; show.ID != 65270 || show.Name != "Avatar: The Last Airbender" {
+		t.Errorf("o show de _embedded deveria ir para Show: %+v", show)
+	}
+	if schedule[0].ID != 2779001 || schedule[0].Runtime != 30 {
+		t.Errorf("campos do episódio incorretos: %+v", schedule[0])
+	}
+}
+
+func TestGetEpisodesAndSeasons_DecodesPayload(t *testing.T) {
+	client := newFixtureTVMaze(t, map[string]string{
+		"/shows/431/episodes": `[
+			{"id": 40646, "name": "The One Where Monica Gets a Roommate", "season": 1, "number": 1,
+			 "airdate": "1994-09-22", "airtime": "20:00", "runtime": 30, "summary": "<p>Rachel leaves Barry.</p>",
+			 "image": {"medium": "https://static.tvmaze.com/e1.jpg", "original": "https://static.tvmaze.com/e1o.jpg"}}
+		]`,
+		"/shows/431/seasons": `[
+			{"id": 479, "number": 1, "name": "", "episodeOrder": 24, "premiereDate": "1994-09-22", "endDate": "1995-05-18",
+			 "network": {"id": 1, "name": "NBC", "country": {"name": "United States", "code": "US", "timezone": "America/New_York"}}}
+		]`,
+		"/seasons/479/episodes": `[{"id": 40646, "season": 1, "number": 1, "name": "The One Where Monica Gets a Roommate"}]`,
+	})
+
+	episodes, err := client.GetEpisodes(context.Background(), "431")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(episodes) != 1 || episodes[0].Season != 1 || episodes[0].Number != 1 || episodes[0].Airtime != "20:00" || episodes[0].Image == nil {
+		t.Errorf("episódios decodificados incorretamente: %+v", episodes)
+	}
+
+	seasons, err := client.GetSeasons(context.Background(), "431")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(seasons) != 1 || seasons[0].ID != 479 || seasons[0].EpisodeOrder != 24 || seasons[0].Network == nil || seasons[0].Network.Name != "NBC" {
+		t.Errorf("temporadas decodificadas incorretamente: %+v", seasons)
+	}
+
+	episodes, err = client.GetSeasonEpisodes(context.Background(), "479")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(episodes) != 1 || episodes[0].ID != 40646 {
+		t.Errorf("episódios da temporada incorretos: %+v", episodes)
+	}
+}
+
+func TestGetCastAndCrew_DecodesPayload(t *testing.T) {
+	client := newFixtureTVMaze(t, map[string]string{
+		"/shows/431/cast": `[
+			{"person": {"id": 14408, "name": "Jennifer Aniston", "country": {"name": "United States", "code": "US", "timezone": "America/New_York"},
+			            "birthday": "1969-02-11", "deathday": null, "gender": "Female"},
+			 "character": {"id": 17426, "name": "Rachel Green", "image": {"medium": "https://static.tvmaze.com/c.jpg"}},
+			 "self": false, "voice": false}
+		]`,
+		"/shows/431/crew": `[{"type": "Creator", "person": {"id": 38913, "name": "David Crane"}}]`,
+	})
+
+	cast, err := client.GetCast(context.Background(), "431")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(cast) != 1 || cast[0].Person.Name != "Jennifer Aniston" || cast[0].Character.Name != "Rachel Green" ||
+		cast[0].Person.Country == nil || cast[0].Person.Deathday != "" || cast[0].Character.Image == nil {
+		t.Errorf("elenco decodificado incorretamente: %+v", cast)
+	}
+
+	crew, err := client.GetCrew(context.Background(), "431")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(crew) != 1 || crew[0].Type != "Creator" || crew[0].Person.ID != 38913 {
+		t.Errorf("equipe decodificada incorretamente: %+v", crew)
+	}
+}
+
+func TestGetPersonCredits_FlattenEmbeddedShows(t *testing.T) {
+	client := newFixtureTVMaze(t, map[string]string{
+		"/people/14408/castcredits?embed=show": `[
+			{"self": false, "voice": true, "_links": {"show": {"href": "https://api.tvmaze.com/shows/431"}},
+			 "_embedded": {"show": {"id": 431, "name": "Friends", "genres": ["Comedy", "Romance"], "premiered": "1994-09-22"}}}
+		]`,
+		"/people/38913/crewcredits?embed=show": `[
+			{"type": "Creator", "_links": {"show": {"href": "https://api.tvmaze.com/shows/431"}},
+			 "_embedded": {"show": {"id": 431, "name": "Friends"}}}
+		]`,
+	})
+
+	cast, err := client.GetPersonCastCredits(context.Background(), "14408")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(cast) != 1 || !cast[0].Voice || cast[0].Show.ID != 431 || cast[0].Show.Name != "Friends" || len(cast[0].Show.Genres) != 2 {
+		t.Errorf("créditos de elenco deveriam trazer o show de _embedded: %+v", cast)
+	}
+
+	crew, err := client.GetPersonCrewCredits(context.Background(), "38913")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(crew) != 1 || crew[0].Type != "Creator" || crew[0].Show.Name != "Friends" {
+		t.Errorf("créditos de equipe deveriam trazer o show de _embedded: %+v", crew)
+	}
+}
+
+func TestGetSchedule_EscapesQuery(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	client := NewTVMazeClient(WithBaseURL(srv.URL))
+	if _, err := client.GetWebSchedule(context.Background(), "US&date=2020-01-01", "2024-03-01"); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if query != "country=US%26date%3D2020-01-01&date=2024-03-01" {
+		t.Errorf("country deveria ir escapado na query: %s", query)
+	}
+}
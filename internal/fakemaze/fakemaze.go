@@ -0,0 +1,308 @@
+// Package fakemaze implementa um substituto local das APIs do TVMaze e do GitHub,
+// servindo respostas gravadas em fixtures JSON. Serve tanto para desenvolvimento
+// sem rede (cmd/fakemaze) quanto para testes com httptest.NewServer(fakemaze.New(...)).
+package fakemaze
+
+import (
+	"embed"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Config controla as falhas e a latência injetadas nas respostas
+type Config struct {
+	Latency       time.Duration // espera antes de cada resposta
+	RateLimitRate float64       // fração das requisições respondidas com 429
+	ErrorRate     float64       // fração das requisições respondidas com 503
+	RetryAfter    time.Duration // valor do header Retry-After nas respostas 429
+}
+
+// Server responde como a TVMaze (/schedule, /schedule/web, /search/shows,
+// /shows/:id) e como o GitHub (/users/:name)
+type Server struct {
+	mu       sync.Mutex
+	config   Config
+	failures []int
+	requests int
+	random   *rand.Rand
+
+	shows        []json.RawMessage
+	showsByID    map[int]json.RawMessage
+	schedules    map[string][]map[string]interface{}
+	webSchedules map[string][]map[string]interface{}
+	users        map[string]json.RawMessage
+	mux          *http.ServeMux
+}
+
+// New cria um servidor com as fixtures embutidas
+func New(config Config) *Server {
+	s := &Server{
+		config:       config,
+		random:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		showsByID:    make(map[int]json.RawMessage),
+		schedules:    make(map[string][]map[string]interface{}),
+		webSchedules: make(map[string][]map[string]interface{}),
+	}
+
+	mustDecode("fixtures/shows.json", &s.shows)
+	for _, raw := range s.shows {
+		var show struct {
+			ID int `json:"id"`
+		}
+		json.Unmarshal(raw, &show)
+		s.showsByID[show.ID] = raw
+	}
+	mustDecode("fixtures/users.json", &s.users)
+
+	// schedule_<PAÍS>.json é a programação de TV e webschedule_<PAÍS>.json a de streaming
+	entries, _ := fixtures.ReadDir("fixtures")
+	for _, entry := range entries {
+		name := entry.Name()
+		for prefix, target := range map[string]map[string][]map[string]interface{}{
+			"schedule_":    s.schedules,
+			"webschedule_": s.webSchedules,
+		} {
+			if strings.HasPrefix(name, prefix) {
+				var schedule []map[string]interface{}
+				mustDecode("fixtures/"+name, &schedule)
+				target[strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".json")] = schedule
+			}
+		}
+	}
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("/schedule", s.schedule)
+	s.mux.HandleFunc("/schedule/web", s.webSchedule)
+	s.mux.HandleFunc("/search/shows", s.search)
+	s.mux.HandleFunc("/shows/", s.show)
+	s.mux.HandleFunc("/users/", s.user)
+	return s
+}
+
+// mustDecode lê uma fixture embutida; falha aqui é erro de build, por isso o panic
+func mustDecode(name string, out interface{}) {
+	data, err := fixtures.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		panic(name + ": " + err.Error())
+	}
+}
+
+// SetConfig altera latência e taxas de falha com o servidor em execução
+func (s *Server) SetConfig(config Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = config
+}
+
+// FailNext faz as próximas n requisições responderem com o status informado,
+// de forma determinística (útil em testes de retry e circuit breaker)
+func (s *Server) FailNext(status, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, status)
+	}
+}
+
+// Requests retorna quantas requisições o servidor recebeu
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// ServeHTTP aplica latência e falhas configuradas e então responde com as fixtures
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	config, status := s.nextOutcome()
+
+	if config.Latency > 0 {
+		select {
+		case <-time.After(config.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	switch status {
+	case 0:
+		s.mux.ServeHTTP(w, r)
+	case http.StatusTooManyRequests:
+		if config.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(config.RetryAfter.Seconds())))
+		}
+		writeStatus(w, status)
+	default:
+		writeStatus(w, status)
+	}
+}
+
+// nextOutcome conta a requisição e decide se ela deve falhar (status != 0)
+func (s *Server) nextOutcome() (Config, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	if len(s.failures) > 0 {
+		status := s.failures[0]
+		s.failures = s.failures[1:]
+		return s.config, status
+	}
+
+	roll := s.random.Float64()
+	switch {
+	case roll < s.config.RateLimitRate:
+		return s.config, http.StatusTooManyRequests
+	case roll < s.config.RateLimitRate+s.config.ErrorRate:
+		return s.config, http.StatusServiceUnavailable
+	}
+	return s.config, 0
+}
+
+// schedule devolve a programação gravada do país, com as datas trocadas pela data pedida
+func (s *Server) schedule(w http.ResponseWriter, r *http.Request) {
+	s.serveSchedule(w, r, s.schedules)
+}
+
+// webSchedule devolve a programação de streaming gravada do país, com o show em _embedded
+func (s *Server) webSchedule(w http.ResponseWriter, r *http.Request) {
+	s.serveSchedule(w, r, s.webSchedules)
+}
+
+// serveSchedule responde com os itens do país pedido levados para a data pedida
+func (s *Server) serveSchedule(w http.ResponseWriter, r *http.Request, schedules map[string][]map[string]interface{}) {
+	country := strings.ToUpper(r.URL.Query().Get("country"))
+	if country == "" {
+		country = "US"
+	}
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		writeStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	schedule := make([]map[string]interface{}, 0, len(schedules[country]))
+	for _, item := range schedules[country] {
+		schedule = append(schedule, onDate(item, date))
+	}
+	json.NewEncoder(w).Encode(schedule)
+}
+
+// onDate copia o item da programação para a data informada, mantendo o horário local.
+// O airstamp é recalculado no fuso da emissora (respeitando horário de verão) ou,
+// sem fuso conhecido, deslocado pelo mesmo número de dias que o airdate.
+func onDate(item map[string]interface{}, date string) map[string]interface{} {
+	copied := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		copied[k] = v
+	}
+
+	recorded, err1 := time.Parse("2006-01-02", stringField(item, "airdate"))
+	target, err2 := time.Parse("2006-01-02", date)
+	airstamp, err3 := time.Parse(time.RFC3339, stringField(item, "airstamp"))
+	if err1 != nil || err2 != nil || err3 != nil {
+		return copied
+	}
+
+	copied["airdate"] = date
+	if loc, err := time.LoadLocation(networkTimezone(item)); err == nil && loc != time.UTC {
+		if local, err := time.ParseInLocation("2006-01-02 15:04", date+" "+stringField(item, "airtime"), loc); err == nil {
+			copied["airstamp"] = local.UTC().Format(time.RFC3339)
+			return copied
+		}
+	}
+	copied["airstamp"] = airstamp.Add(target.Sub(recorded)).Format(time.RFC3339)
+	return copied
+}
+
+// networkTimezone retorna o fuso do país da emissora ou do canal de streaming do
+// show do item (em show ou, na programação web, em _embedded.show), se houver
+func networkTimezone(item map[string]interface{}) string {
+	show, ok := item["show"].(map[string]interface{})
+	if !ok {
+		embedded, _ := item["_embedded"].(map[string]interface{})
+		show, _ = embedded["show"].(map[string]interface{})
+	}
+	for _, key := range []string{"network", "webChannel"} {
+		channel, _ := show[key].(map[string]interface{})
+		country, _ := channel["country"].(map[string]interface{})
+		if tz := stringField(country, "timezone"); tz != "" {
+			return tz
+		}
+	}
+	return ""
+}
+
+func stringField(item map[string]interface{}, key string) string {
+	v, _ := item[key].(string)
+	return v
+}
+
+// search busca nas fixtures os shows cujo nome contém q
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("q")))
+
+	type result struct {
+		Score float64         `json:"score"`
+		Show  json.RawMessage `json:"show"`
+	}
+	results := []result{}
+	if query != "" {
+		for _, raw := range s.shows {
+			var show struct {
+				Name string `json:"name"`
+			}
+			json.Unmarshal(raw, &show)
+			name := strings.ToLower(show.Name)
+			if strings.Contains(name, query) {
+				results = append(results, result{Score: float64(len(query)) / float64(len(name)), Show: raw})
+			}
+		}
+	}
+	json.NewEncoder(w).Encode(results)
+}
+
+// show devolve um show pelo ID
+func (s *Server) show(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(r.URL.Path, "/shows/"), "/"))
+	raw, ok := s.showsByID[id]
+	if err != nil || !ok {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	w.Write(raw)
+}
+
+// user devolve um usuário do GitHub pelo login
+func (s *Server) user(w http.ResponseWriter, r *http.Request) {
+	raw, ok := s.users[strings.ToLower(strings.Trim(strings.TrimPrefix(r.URL.Path, "/users/"), "/"))]
+	if !ok {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	w.Write(raw)
+}
+
+// writeStatus responde com o corpo de erro no formato usado pela TVMaze
+func writeStatus(w http.ResponseWriter, status int) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"name":    http.StatusText(status),
+		"message": http.StatusText(status),
+		"status":  status,
+	})
+}
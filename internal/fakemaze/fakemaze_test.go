@@ -0,0 +1,101 @@
+package fakemaze_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github-api-demo/internal/clients"
+	"github-api-demo/internal/fakemaze"
+)
+
+func TestFakemaze_ServesFixturesToClients(t *testing.T) {
+	srv := httptest.NewServer(fakemaze.New(fakemaze.Config{}))
+	defer srv.Close()
+
+	tvmaze := clients.NewTVMazeClient(clients.WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	show, err := tvmaze.GetShowByID(ctx, "431")
+	if err != nil || show.Name != "Friends" {
+		t.Fatalf("show incorreto: %+v %v", show, err)
+	}
+
+	results, err := tvmaze.SearchShows(ctx, "thrones")
+	if err != nil || len(results) != 1 || results[0].Show.ID != 82 {
+		t.Errorf("busca incorreta: %+v %v", results, err)
+	}
+
+	schedule, err := tvmaze.GetSchedule(ctx, "US", "2025-12-24")
+	if err != nil || len(schedule) == 0 {
+		t.Fatalf("programação vazia: %v", err)
+	}
+	if schedule[0].Airdate != "2025-12-24" || schedule[0].Airstamp != "2025-12-25T01:00:00Z" {
+		t.Errorf("datas não foram ajustadas: %s %s", schedule[0].Airdate, schedule[0].Airstamp)
+	}
+
+	if _, err := tvmaze.GetShowByID(ctx, "999999"); !errors.Is(err, clients.ErrNotFound) {
+		t.Errorf("esperado não encontrado, obtido %v", err)
+	}
+
+	github := clients.NewGitHubClient(clients.WithBaseURL(srv.URL))
+	user, err := github.GetUser(ctx, "octocat")
+	if err != nil || user.Login != "octocat" {
+		t.Errorf("usuário incorreto: %+v %v", user, err)
+	}
+}
+
+func TestFakemaze_WebScheduleAndTimezones(t *testing.T) {
+	srv := httptest.NewServer(fakemaze.New(fakemaze.Config{}))
+	defer srv.Close()
+
+	tvmaze := clients.NewTVMazeClient(clients.WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	web, err := tvmaze.GetWebSchedule(ctx, "US", "2024-07-01")
+	if err != nil || len(web) != 2 {
+		t.Fatalf("programação web incorreta: %+v %v", web, err)
+	}
+	if web[0].Show.Name != "Stranger Things" || web[0].Airstamp != "2024-07-01T12:00:00Z" {
+		t.Errorf("item sem fuso deveria ser deslocado pelo número de dias: %+v", web[0])
+	}
+	// 21:00 em Nova York no horário de verão (UTC-4)
+	if web[1].Show.WebChannel == nil || web[1].Airstamp != "2024-07-02T01:00:00Z" {
+		t.Errorf("airstamp deveria seguir o fuso do canal de streaming: %+v", web[1])
+	}
+
+	br, err := tvmaze.GetSchedule(ctx, "BR", "2024-07-01")
+	if err != nil || len(br) == 0 {
+		t.Fatalf("programação BR vazia: %v", err)
+	}
+	if br[0].Show.Network.Country.Timezone != "America/Sao_Paulo" || br[0].Airstamp != "2024-07-01T23:30:00Z" {
+		t.Errorf("fuso e airstamp do BR inconsistentes: %s %s", br[0].Show.Network.Country.Timezone, br[0].Airstamp)
+	}
+}
+
+func TestFakemaze_InjectsFailures(t *testing.T) {
+	fake := fakemaze.New(fakemaze.Config{})
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	fake.FailNext(http.StatusServiceUnavailable, 1)
+	tvmaze := clients.NewTVMazeClient(clients.WithBaseURL(srv.URL))
+	if _, err := tvmaze.GetShowByID(context.Background(), "431"); err != nil {
+		t.Fatalf("o retry deveria recuperar a falha: %v", err)
+	}
+	if fake.Requests() != 2 {
+		t.Errorf("esperado 2 requisições, obtido %d", fake.Requests())
+	}
+
+	fake.SetConfig(fakemaze.Config{RateLimitRate: 1})
+	resp, err := http.Get(srv.URL + "/shows/431")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("esperado 429, obtido %d", resp.StatusCode)
+	}
+}
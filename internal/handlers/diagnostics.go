@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github-api-demo/internal/clients"
+	"github-api-demo/internal/models"
+)
+
+// BreakerReporter é implementado pelos clientes que expõem o estado do circuit breaker
+type BreakerReporter interface {
+	BreakerStats() clients.BreakerStats
+}
+
+// TVMazeReporter expõe as estatísticas do cliente do TVMaze
+type TVMazeReporter interface {
+	BreakerReporter
+	CoalescingStats() clients.CoalescingStats
+}
+
+// DiagnosticsHandler expõe o estado interno dos clientes das APIs externas
+type DiagnosticsHandler struct {
+	tvmazeClient TVMazeReporter
+	githubClient BreakerReporter
+}
+
+// NewDiagnosticsHandler cria uma nova instância do handler
+func NewDiagnosticsHandler(tvmazeClient TVMazeReporter, githubClient BreakerReporter) *DiagnosticsHandler {
+	return &DiagnosticsHandler{
+		tvmazeClient: tvmazeClient,
+		githubClient: githubClient,
+	}
+}
+
+// Upstreams retorna as estatísticas de cada API externa
+func (h *DiagnosticsHandler) Upstreams(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	json.NewEncoder(w).Encode(models.Response{
+		Success: true,
+		Data: map[string]interface{}{
+			"tvmaze": map[string]interface{}{
+				"circuit_breaker": h.tvmazeClient.BreakerStats(),
+				"coalescing":      h.tvmazeClient.CoalescingStats(),
+			},
+			"github": map[string]interface{}{
+				"circuit_breaker": h.githubClient.BreakerStats(),
+			},
+		},
+	})
+}
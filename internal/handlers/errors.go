@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github-api-demo/internal/clients"
+	"github-api-demo/internal/models"
+	"github-api-demo/internal/requestid"
+	"github-api-demo/internal/services"
+)
+
+// errorStatus associa cada categoria de erro ao status HTTP e ao código da resposta
+var errorStatus = []struct {
+	kind   error
+	status int
+	code   string
+}{
+	{services.ErrInvalidInput, http.StatusBadRequest, models.CodeInvalidInput},
+	{services.ErrNotFound, http.StatusNotFound, models.CodeNotFound},
+	{services.ErrRateLimited, http.StatusTooManyRequests, models.CodeRateLimited},
+	{services.ErrUpstreamUnavailable, http.StatusServiceUnavailable, models.CodeUpstreamUnavailable},
+	{services.ErrUpstream, http.StatusBadGateway, models.CodeUpstreamError},
+}
+
+// writeError escreve a resposta de erro padrão, escolhendo o status e o código
+// pela categoria do erro. Erros sem categoria viram 500. Quando a API externa
+// sugere uma espera (429 ou circuito aberto), repassa o Retry-After.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status, code := http.StatusInternalServerError, models.CodeInternal
+	for _, e := range errorStatus {
+		if errors.Is(err, e.kind) {
+			status, code = e.status, e.code
+			break
+		}
+	}
+
+	if seconds, ok := clients.RetryAfterSeconds(err); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+
+	writeFailure(w, r, status, code, err.Error())
+}
+
+// writeFailure escreve uma resposta de erro com status, código e mensagem explícitos.
+// Inclui o ID da requisição, para correlacionar o erro relatado com os logs.
+func writeFailure(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.Response{
+		Success:   false,
+		Error:     message,
+		Code:      code,
+		RequestID: requestid.FromContext(r.Context()),
+	})
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github-api-demo/internal/clients"
+	"github-api-demo/internal/models"
+	"github-api-demo/internal/requestid"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		status     int
+		code       string
+		retryAfter string
+	}{
+		{"entrada inválida", clients.NewError(clients.ErrInvalidInput, "ID não pode ser vazio"), http.StatusBadRequest, models.CodeInvalidInput, ""},
+		{"não encontrado", clients.NewError(clients.ErrNotFound, "usuário não encontrado"), http.StatusNotFound, models.CodeNotFound, ""},
+		{"limite de requisições", &clients.Error{Kind: clients.ErrRateLimited, Message: "limite atingido", RetryAfter: 30 * time.Second}, http.StatusTooManyRequests, models.CodeRateLimited, "30"},
+		{"erro da API externa", fmt.Errorf("buscando show: %w", clients.NewError(clients.ErrUpstream, "status code: 418")), http.StatusBadGateway, models.CodeUpstreamError, ""},
+		{"API indisponível", clients.NewError(clients.ErrUpstreamUnavailable, "status code: 503"), http.StatusServiceUnavailable, models.CodeUpstreamUnavailable, ""},
+		{"circuito aberto", &clients.CircuitOpenError{Upstream: "tvmaze", RetryAfter: 12 * time.Second}, http.StatusServiceUnavailable, models.CodeUpstreamUnavailable, "12"},
+		{"sem categoria", errors.New("falha inesperada"), http.StatusInternalServerError, models.CodeInternal, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/show?id=1", nil)
+			req = req.WithContext(requestid.WithID(req.Context(), "req-1"))
+			rec := httptest.NewRecorder()
+
+			writeError(rec, req, tt.err)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, esperado %d", rec.Code, tt.status)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.retryAfter {
+				t.Errorf("Retry-After = %q, esperado %q", got, tt.retryAfter)
+			}
+
+			var body models.Response
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Success || body.Code != tt.code || body.Error != tt.err.Error() || body.RequestID != "req-1" {
+				t.Errorf("corpo incorreto: %+v", body)
+			}
+		})
+	}
+}
@@ -43,25 +43,13 @@ func (h *GitHubHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	
 	username := r.URL.Query().Get("username")
 	if username == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(models.Response{
-			Success: false,
-			Error:   "Parâmetro 'username' é obrigatório. Use: /api/user?username=USERNAME",
-		})
+		writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'username' é obrigatório. Use: /api/user?username=USERNAME")
 		return
 	}
 	
-	user, err := h.service.GetUser(username)
+	user, err := h.service.GetUser(r.Context(), username)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		if err.Error() == "usuário não encontrado" {
-			statusCode = http.StatusNotFound
-		}
-		w.WriteHeader(statusCode)
-		json.NewEncoder(w).Encode(models.Response{
-			Success: false,
-			Error:   err.Error(),
-		})
+		writeError(w, r, err)
 		return
 	}
 	
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github-api-demo/internal/models"
+	"github-api-demo/internal/services"
+)
+
+// PeopleHandler contém os handlers para pessoas
+type PeopleHandler struct {
+	service *services.PeopleService
+}
+
+// NewPeopleHandler cria uma nova instância do handler
+func NewPeopleHandler(service *services.PeopleService) *PeopleHandler {
+	return &PeopleHandler{
+		service: service,
+	}
+}
+
+// Search busca pessoas pelo nome
+func (h *PeopleHandler) Search(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'q' é obrigatório. Use: /search/people?q=NOME")
+		return
+	}
+
+	meta := &services.RequestMeta{}
+	results, err := h.service.ForRequest(meta).Search(r.Context(), query)
+	setCacheHeader(w, meta)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.Response{
+		Success: true,
+		Data:    results,
+		Count:   len(results),
+	})
+}
+
+// People atende as rotas /people/:id, /people/:id/castcredits e /people/:id/crewcredits
+func (h *PeopleHandler) People(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/people/"), "/"), "/")
+	if parts[0] == "" || len(parts) > 2 {
+		writeFailure(w, r, http.StatusNotFound, models.CodeNotFound, "Rota não encontrada. Use: /people/ID, /people/ID/castcredits ou /people/ID/crewcredits")
+		return
+	}
+
+	id := parts[0]
+	resource := ""
+	if len(parts) == 2 {
+		resource = parts[1]
+	}
+
+	var data interface{}
+	var count int
+	var err error
+
+	meta := &services.RequestMeta{}
+	service := h.service.ForRequest(meta)
+
+	switch resource {
+	case "":
+		data, err = service.GetPerson(r.Context(), id)
+	case "castcredits":
+		var credits []models.PersonCastCredit
+		credits, err = service.GetCastCredits(r.Context(), id)
+		data, count = credits, len(credits)
+	case "crewcredits":
+		var credits []models.PersonCrewCredit
+		credits, err = service.GetCrewCredits(r.Context(), id)
+		data, count = credits, len(credits)
+	default:
+		writeFailure(w, r, http.StatusNotFound, models.CodeNotFound, "Rota não encontrada. Use: /people/ID, /people/ID/castcredits ou /people/ID/crewcredits")
+		return
+	}
+
+	setCacheHeader(w, meta)
+
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.Response{
+		Success: true,
+		Data:    data,
+		Count:   count,
+	})
+}
@@ -2,7 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github-api-demo/internal/models"
@@ -25,32 +29,66 @@ func NewTVMazeHandler(service *services.TVMazeService) *TVMazeHandler {
 func (h *TVMazeHandler) Home(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	
+	now := h.service.Now()
+	
 	info := map[string]interface{}{
 		"message": "📺 API Go - TVMaze Schedule",
 		"version": "3.0.0",
-		"date":    time.Now().Format("2006-01-02"),
-		"time":    time.Now().Format("15:04"),
+		"date":    now.Format("2006-01-02"),
+		"time":    now.Format("15:04"),
 		"docs":    "/docs - 📚 Documentação Interativa",
 		"endpoints": map[string]string{
 			"GET /":                      "Informações da API",
 			"GET /docs":                  "📚 Documentação Interativa (Swagger-like)",
 			"GET /schedule":              "Programação de hoje (país padrão: US)",
 			"GET /schedule?country=BR":   "Programação de hoje no Brasil",
-			"GET /search?q=NOME":         "Buscar shows por nome",
+			"GET /schedule?date=AAAA-MM-DD": "Programação de uma data específica",
+			"GET /schedule?from=AAAA-MM-DD&to=AAAA-MM-DD": "Programação de um período (máx. 14 dias)",
+			"GET /schedule?days=N":       "Programação dos próximos N dias",
+			"GET /schedule?source=all":   "Programação de TV e streaming (tv, web ou all)",
+			"GET /schedule/web":          "Programação de streaming (Netflix, Prime Video...)",
+			"GET /search?q=NOME":         "Buscar shows por nome (filtros: min_score, limit, genre, language)",
 			"GET /show?id=ID":            "Detalhes de um show específico",
+			"GET /lookup?imdb=ID":        "Buscar show pelo ID do IMDb (ou thetvdb, tvrage)",
+			"GET /show/cast?id=ID":       "Elenco de um show",
+			"GET /show/crew?id=ID":       "Equipe técnica de um show",
 			"GET /genre?genre=GENERO":    "Programação filtrada por gênero/categoria",
 			"GET /now":                   "O que está passando agora",
+			"GET /now?country=BR&tz=America/Sao_Paulo": "O que está passando agora, com horário no fuso informado",
+			"GET /now?country=BR&tz=America/Sao_Paulo&at=21:00": "O que estará passando às 21:00 de hoje (ou em um instante RFC3339)",
+			"GET /upcoming?within=2h":    "Episódios que começam nas próximas horas",
+			"GET /shows/ID/episodes":      "Lista de episódios de um show",
+			"GET /shows/ID/seasons":       "Temporadas de um show",
+			"GET /seasons/ID/episodes":    "Episódios de uma temporada",
+			"GET /search/people?q=NOME":  "Buscar pessoas por nome",
+			"GET /people/ID":             "Detalhes de uma pessoa",
+			"GET /people/ID/castcredits": "Shows em que a pessoa atuou",
+			"GET /people/ID/crewcredits": "Shows em que a pessoa fez parte da equipe",
 			"GET /api/user?username=USER": "Informações de usuário do GitHub",
+			"GET /diagnostics":           "Estatísticas das chamadas às APIs externas",
+			"GET /metrics":               "Métricas no formato do Prometheus",
 		},
 		"examples": []string{
 			"/docs",
 			"/schedule",
 			"/schedule?country=BR",
+			"/schedule?days=7&country=US",
+			"/schedule/web?country=US",
 			"/search?q=friends",
+			"/search?q=friends&genre=Comedy&language=English&limit=3",
 			"/show?id=431",
+			"/lookup?imdb=tt0944947",
+			"/show/cast?id=431",
+			"/show/crew?id=431",
 			"/genre?genre=Sports&country=US",
 			"/genre?genre=Drama&country=BR",
 			"/now?country=US",
+			"/upcoming?country=US&within=2h",
+			"/shows/431/episodes",
+			"/shows/431/seasons",
+			"/seasons/1/episodes",
+			"/search/people?q=bryan+cranston",
+			"/people/1/castcredits",
 			"/api/user?username=patrickbathu",
 		},
 		"genres": []string{
@@ -63,8 +101,30 @@ func (h *TVMazeHandler) Home(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(info)
 }
 
-// Schedule retorna a programação de hoje
+// Schedule retorna a programação de hoje, de uma data ou de um período
 func (h *TVMazeHandler) Schedule(w http.ResponseWriter, r *http.Request) {
+	source := services.ScheduleSource(r.URL.Query().Get("source"))
+	if source == "" {
+		source = services.SourceTV
+	}
+	
+	if source != services.SourceTV && source != services.SourceWeb && source != services.SourceAll {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'source' deve ser tv, web ou all. Use: /schedule?source=all")
+		return
+	}
+	
+	h.schedule(w, r, source)
+}
+
+// WebSchedule retorna a programação de streaming (Netflix, Prime Video...)
+func (h *TVMazeHandler) WebSchedule(w http.ResponseWriter, r *http.Request) {
+	h.schedule(w, r, services.SourceWeb)
+}
+
+// schedule responde com a programação da fonte indicada para o país e período pedidos
+func (h *TVMazeHandler) schedule(w http.ResponseWriter, r *http.Request, source services.ScheduleSource) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	
@@ -73,21 +133,85 @@ func (h *TVMazeHandler) Schedule(w http.ResponseWriter, r *http.Request) {
 		country = "US"
 	}
 	
-	schedule, err := h.service.GetTodaySchedule(country)
+	from, to, err := parseScheduleRange(r.URL.Query(), h.service.Now())
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(models.Response{
-			Success: false,
-			Error:   err.Error(),
-		})
+		writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, err.Error())
 		return
 	}
 	
-	json.NewEncoder(w).Encode(models.Response{
+	meta := &services.RequestMeta{}
+	schedule, err := h.service.ForRequest(meta).GetScheduleRange(r.Context(), country, source, from, to)
+	setCacheHeader(w, meta)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	
+	response := models.Response{
 		Success: true,
 		Data:    schedule,
 		Count:   len(schedule),
-	})
+	}
+	markStale(&response, meta)
+	
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseScheduleRange interpreta os parâmetros date, from/to e days de /schedule.
+// Sem nenhum deles, o período é apenas o dia de hoje.
+func parseScheduleRange(query url.Values, now time.Time) (time.Time, time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	
+	// date, from/to e days são formas excludentes de escolher o período
+	given := 0
+	for _, forms := range [][]string{{"date"}, {"from", "to"}, {"days"}} {
+		for _, name := range forms {
+			if query.Get(name) != "" {
+				given++
+				break
+			}
+		}
+	}
+	if given > 1 {
+		return time.Time{}, time.Time{}, fmt.Errorf("Use apenas uma forma de período: 'date', 'from'/'to' ou 'days'")
+	}
+	
+	if v := query.Get("days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 1 || days > services.MaxScheduleDays {
+			return time.Time{}, time.Time{}, fmt.Errorf("Parâmetro 'days' deve ser um inteiro entre 1 e %d", services.MaxScheduleDays)
+		}
+		return today, today.AddDate(0, 0, days-1), nil
+	}
+	
+	if v := query.Get("from"); v != "" {
+		from, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("Parâmetro 'from' deve estar no formato AAAA-MM-DD")
+		}
+		to := from
+		if v := query.Get("to"); v != "" {
+			to, err = time.Parse("2006-01-02", v)
+			if err != nil {
+				return time.Time{}, time.Time{}, fmt.Errorf("Parâmetro 'to' deve estar no formato AAAA-MM-DD")
+			}
+		}
+		return from, to, nil
+	}
+	
+	if query.Get("to") != "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("Parâmetro 'to' exige 'from'. Use: /schedule?from=AAAA-MM-DD&to=AAAA-MM-DD")
+	}
+	
+	if v := query.Get("date"); v != "" {
+		date, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("Parâmetro 'date' deve estar no formato AAAA-MM-DD")
+		}
+		return date, date, nil
+	}
+	
+	return today, today, nil
 }
 
 // Search busca shows
@@ -97,21 +221,38 @@ func (h *TVMazeHandler) Search(w http.ResponseWriter, r *http.Request) {
 	
 	query := r.URL.Query().Get("q")
 	if query == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(models.Response{
-			Success: false,
-			Error:   "Parâmetro 'q' é obrigatório. Use: /search?q=NOME",
-		})
+		writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'q' é obrigatório. Use: /search?q=NOME")
 		return
 	}
 	
-	results, err := h.service.SearchShows(query)
+	filter := services.SearchFilter{
+		Genre:    r.URL.Query().Get("genre"),
+		Language: r.URL.Query().Get("language"),
+	}
+	
+	if v := r.URL.Query().Get("min_score"); v != "" {
+		minScore, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'min_score' deve ser numérico. Use: /search?q=NOME&min_score=0.5")
+			return
+		}
+		filter.MinScore = minScore
+	}
+	
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'limit' deve ser um inteiro positivo. Use: /search?q=NOME&limit=5")
+			return
+		}
+		filter.Limit = limit
+	}
+	
+	meta := &services.RequestMeta{}
+	results, err := h.service.ForRequest(meta).SearchShows(r.Context(), query, filter)
+	setCacheHeader(w, meta)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(models.Response{
-			Success: false,
-			Error:   err.Error(),
-		})
+		writeError(w, r, err)
 		return
 	}
 	
@@ -129,21 +270,47 @@ func (h *TVMazeHandler) ShowDetails(w http.ResponseWriter, r *http.Request) {
 	
 	id := r.URL.Query().Get("id")
 	if id == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(models.Response{
-			Success: false,
-			Error:   "Parâmetro 'id' é obrigatório. Use: /show?id=123",
-		})
+		writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'id' é obrigatório. Use: /show?id=123")
+		return
+	}
+	
+	meta := &services.RequestMeta{}
+	show, err := h.service.ForRequest(meta).GetShowByID(r.Context(), id)
+	setCacheHeader(w, meta)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	
+	json.NewEncoder(w).Encode(models.Response{
+		Success: true,
+		Data:    show,
+	})
+}
+
+// Lookup busca um show pelo ID do IMDb, TheTVDB ou TVRage
+func (h *TVMazeHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	
+	var source, id string
+	for _, s := range services.LookupSources {
+		if v := r.URL.Query().Get(s); v != "" {
+			source, id = s, v
+			break
+		}
+	}
+	
+	if id == "" {
+		writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Informe 'imdb', 'thetvdb' ou 'tvrage'. Use: /lookup?imdb=tt0944947")
 		return
 	}
 	
-	show, err := h.service.GetShowByID(id)
+	meta := &services.RequestMeta{}
+	show, err := h.service.ForRequest(meta).LookupShow(r.Context(), source, id)
+	setCacheHeader(w, meta)
 	if err != nil {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(models.Response{
-			Success: false,
-			Error:   err.Error(),
-		})
+		writeError(w, r, err)
 		return
 	}
 	
@@ -153,6 +320,58 @@ func (h *TVMazeHandler) ShowDetails(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ShowCast retorna o elenco de um show
+func (h *TVMazeHandler) ShowCast(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'id' é obrigatório. Use: /show/cast?id=123")
+		return
+	}
+	
+	meta := &services.RequestMeta{}
+	cast, err := h.service.ForRequest(meta).GetCast(r.Context(), id)
+	setCacheHeader(w, meta)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	
+	json.NewEncoder(w).Encode(models.Response{
+		Success: true,
+		Data:    cast,
+		Count:   len(cast),
+	})
+}
+
+// ShowCrew retorna a equipe técnica de um show
+func (h *TVMazeHandler) ShowCrew(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'id' é obrigatório. Use: /show/crew?id=123")
+		return
+	}
+	
+	meta := &services.RequestMeta{}
+	crew, err := h.service.ForRequest(meta).GetCrew(r.Context(), id)
+	setCacheHeader(w, meta)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	
+	json.NewEncoder(w).Encode(models.Response{
+		Success: true,
+		Data:    crew,
+		Count:   len(crew),
+	})
+}
+
 // Genre retorna programação por gênero
 func (h *TVMazeHandler) Genre(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -160,11 +379,7 @@ func (h *TVMazeHandler) Genre(w http.ResponseWriter, r *http.Request) {
 	
 	genre := r.URL.Query().Get("genre")
 	if genre == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(models.Response{
-			Success: false,
-			Error:   "Parâmetro 'genre' é obrigatório. Use: /genre?genre=Sports&country=US",
-		})
+		writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'genre' é obrigatório. Use: /genre?genre=Sports&country=US")
 		return
 	}
 	
@@ -173,21 +388,22 @@ func (h *TVMazeHandler) Genre(w http.ResponseWriter, r *http.Request) {
 		country = "US"
 	}
 	
-	schedule, err := h.service.GetScheduleByGenre(country, genre)
+	meta := &services.RequestMeta{}
+	schedule, err := h.service.ForRequest(meta).GetScheduleByGenre(r.Context(), country, genre)
+	setCacheHeader(w, meta)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(models.Response{
-			Success: false,
-			Error:   err.Error(),
-		})
+		writeError(w, r, err)
 		return
 	}
 	
-	json.NewEncoder(w).Encode(models.Response{
+	response := models.Response{
 		Success: true,
 		Data:    schedule,
 		Count:   len(schedule),
-	})
+	}
+	markStale(&response, meta)
+	
+	json.NewEncoder(w).Encode(response)
 }
 
 // NowPlaying retorna o que está passando agora
@@ -200,23 +416,206 @@ func (h *TVMazeHandler) NowPlaying(w http.ResponseWriter, r *http.Request) {
 		country = "US"
 	}
 	
-	nowPlaying, err := h.service.GetNowPlaying(country)
+	// Sem tz explícito, usa o fuso do país pedido, inclusive para interpretar 'at'
+	loc, known := services.CountryLocation(country)
+	if tz := r.URL.Query().Get("tz"); tz != "" {
+		var err error
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'tz' inválido. Use um fuso IANA, ex: /now?country=BR&tz=America/Sao_Paulo")
+			return
+		}
+		known = true
+	}
+	
+	at := h.service.Now()
+	if value := r.URL.Query().Get("at"); value != "" {
+		var err error
+		at, err = parseAt(value, at, loc)
+		if err != nil {
+			writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'at' inválido. Use HH:MM, AAAA-MM-DDTHH:MM ou RFC3339, ex: /now?country=BR&tz=America/Sao_Paulo&at=21:00")
+			return
+		}
+	}
+	
+	meta := &services.RequestMeta{}
+	nowPlaying, err := h.service.ForRequest(meta).GetPlayingAt(r.Context(), country, at)
+	setCacheHeader(w, meta)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(models.Response{
-			Success: false,
-			Error:   err.Error(),
-		})
+		writeError(w, r, err)
 		return
 	}
 	
+	// País fora da tabela de fusos: usa o fuso da emissora, se houver programação
+	if !known && len(nowPlaying) > 0 {
+		loc = services.ScheduleLocation(nowPlaying[0])
+	}
+	
 	response := map[string]interface{}{
 		"success":      true,
-		"current_time": time.Now().Format("15:04"),
+		"current_time": at.In(loc).Format("15:04"),
+		"timezone":     loc.String(),
 		"country":      country,
 		"data":         nowPlaying,
 		"count":        len(nowPlaying),
 	}
+	if stale, age := meta.Stale(); stale {
+		response["stale"] = true
+		response["stale_age_seconds"] = int(age.Seconds())
+	}
+	
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseAt interpreta o parâmetro 'at' de /now: um instante RFC3339, uma data e
+// hora (AAAA-MM-DDTHH:MM) ou apenas um horário (HH:MM) no dia de now. Sem fuso
+// explícito, data e horário são interpretados em loc (UTC quando nil).
+func parseAt(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return at, nil
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	if at, err := time.ParseInLocation("2006-01-02T15:04", value, loc); err == nil {
+		return at, nil
+	}
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	day := now.In(loc)
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, loc), nil
+}
+
+// Upcoming retorna os episódios que começam dentro da janela informada
+func (h *TVMazeHandler) Upcoming(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	
+	country := r.URL.Query().Get("country")
+	if country == "" {
+		country = "US"
+	}
+	
+	within := time.Hour
+	if v := r.URL.Query().Get("within"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < time.Minute || d > services.MaxUpcomingWindow {
+			writeFailure(w, r, http.StatusBadRequest, models.CodeInvalidInput, "Parâmetro 'within' deve ser uma duração entre 1m e 24h. Use: /upcoming?within=2h")
+			return
+		}
+		within = d
+	}
+	
+	meta := &services.RequestMeta{}
+	upcoming, err := h.service.ForRequest(meta).GetUpcoming(r.Context(), country, within)
+	setCacheHeader(w, meta)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	
+	response := map[string]interface{}{
+		"success": true,
+		"within":  within.String(),
+		"country": country,
+		"data":    upcoming,
+		"count":   len(upcoming),
+	}
+	if stale, age := meta.Stale(); stale {
+		response["stale"] = true
+		response["stale_age_seconds"] = int(age.Seconds())
+	}
 	
 	json.NewEncoder(w).Encode(response)
 }
+
+// Shows atende as rotas /shows/:id/episodes e /shows/:id/seasons
+func (h *TVMazeHandler) Shows(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/shows/"), "/"), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		writeFailure(w, r, http.StatusNotFound, models.CodeNotFound, "Rota não encontrada. Use: /shows/ID/episodes ou /shows/ID/seasons")
+		return
+	}
+	
+	id := parts[0]
+	
+	var data interface{}
+	var count int
+	var err error
+	
+	meta := &services.RequestMeta{}
+	service := h.service.ForRequest(meta)
+	
+	switch parts[1] {
+	case "episodes":
+		var episodes []models.Episode
+		episodes, err = service.GetEpisodes(r.Context(), id)
+		data, count = episodes, len(episodes)
+	case "seasons":
+		var seasons []models.Season
+		seasons, err = service.GetSeasons(r.Context(), id)
+		data, count = seasons, len(seasons)
+	default:
+		writeFailure(w, r, http.StatusNotFound, models.CodeNotFound, "Rota não encontrada. Use: /shows/ID/episodes ou /shows/ID/seasons")
+		return
+	}
+	
+	setCacheHeader(w, meta)
+	
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	
+	json.NewEncoder(w).Encode(models.Response{
+		Success: true,
+		Data:    data,
+		Count:   count,
+	})
+}
+
+// SeasonEpisodes atende a rota /seasons/:id/episodes
+func (h *TVMazeHandler) SeasonEpisodes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/seasons/"), "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "episodes" {
+		writeFailure(w, r, http.StatusNotFound, models.CodeNotFound, "Rota não encontrada. Use: /seasons/ID/episodes")
+		return
+	}
+	
+	meta := &services.RequestMeta{}
+	episodes, err := h.service.ForRequest(meta).GetSeasonEpisodes(r.Context(), parts[0])
+	setCacheHeader(w, meta)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	
+	json.NewEncoder(w).Encode(models.Response{
+		Success: true,
+		Data:    episodes,
+		Count:   len(episodes),
+	})
+}
+
+// setCacheHeader informa no header X-Cache se a resposta veio inteiramente do cache
+func setCacheHeader(w http.ResponseWriter, meta *services.RequestMeta) {
+	if status := meta.Cache.Status(); status != "" {
+		w.Header().Set("X-Cache", status)
+	}
+}
+
+// markStale sinaliza na resposta quando os dados vieram do estoque de última versão válida
+func markStale(response *models.Response, meta *services.RequestMeta) {
+	if stale, age := meta.Stale(); stale {
+		response.Stale = true
+		response.StaleAgeSeconds = int(age.Seconds())
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github-api-demo/internal/clients"
+	"github-api-demo/internal/models"
+	"github-api-demo/internal/services"
+)
+
+func TestParseScheduleRange(t *testing.T) {
+	now := time.Date(2024, 1, 10, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		query    string
+		from, to time.Time
+		wantErr  bool
+	}{
+		{query: "", from: today, to: today},
+		{query: "date=2024-02-01", from: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), to: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{query: "from=2024-01-01&to=2024-01-07", from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), to: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{query: "days=7", from: today, to: today.AddDate(0, 0, 6)},
+		{query: "days=0", wantErr: true},
+		{query: "from=01/01/2024", wantErr: true},
+		{query: "to=2024-01-07", wantErr: true},
+		{query: "date=2024-01-01&days=7", wantErr: true},
+		{query: "date=2024-01-01&from=2024-01-01", wantErr: true},
+		{query: "from=2024-01-01&to=2024-01-07&days=7", wantErr: true},
+		{query: "to=2024-01-07&days=7", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		from, to, err := parseScheduleRange(query, now)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: esperado erro", tt.query)
+			}
+			continue
+		}
+		if err != nil || !from.Equal(tt.from) || !to.Equal(tt.to) {
+			t.Errorf("%q: obtido %v..%v (%v), esperado %v..%v", tt.query, from, to, err, tt.from, tt.to)
+		}
+	}
+}
+
+func TestNowPlaying_TimezoneFromCountryWhenNothingAiring(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer upstream.Close()
+
+	service := services.NewTVMazeService(clients.NewTVMazeClient(clients.WithBaseURL(upstream.URL)))
+	service.SetClock(func() time.Time { return time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC) })
+	handler := NewTVMazeHandler(service)
+
+	rec := httptest.NewRecorder()
+	handler.NowPlaying(rec, httptest.NewRequest("GET", "/now?country=BR", nil))
+
+	var body struct {
+		CurrentTime string `json:"current_time"`
+		Timezone    string `json:"timezone"`
+		Count       int    `json:"count"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Count != 0 || body.Timezone != "America/Sao_Paulo" || body.CurrentTime != "12:00" {
+		t.Errorf("sem programação, o horário deveria estar no fuso do país: %+v", body)
+	}
+}
+
+func TestSchedule_ConflictingRangeParams(t *testing.T) {
+	handler := NewTVMazeHandler(services.NewTVMazeService(clients.NewTVMazeClient(clients.WithBaseURL("http://127.0.0.1:0"))))
+
+	rec := httptest.NewRecorder()
+	handler.Schedule(rec, httptest.NewRequest("GET", "/schedule?date=2024-01-01&days=7", nil))
+
+	var body struct {
+		Code string `json:"code"`
+	}
+	json.NewDecoder(rec.Body).Decode(&body)
+	if rec.Code != http.StatusBadRequest || body.Code != models.CodeInvalidInput {
+		t.Errorf("parâmetros de período conflitantes deveriam dar 400, obtido %d %q", rec.Code, body.Code)
+	}
+}
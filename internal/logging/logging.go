@@ -0,0 +1,50 @@
+// Package logging configura o logger estruturado (log/slog) da aplicação e
+// carrega no contexto o logger de cada requisição, para que handlers, serviços
+// e clientes registrem eventos com os mesmos campos (request_id, método, rota...).
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+type contextKey struct{}
+
+// New cria um logger no formato informado ("json" ou "text") e nível mínimo
+// ("debug", "info", "warn" ou "error"). Valores desconhecidos usam text e info.
+func New(w io.Writer, format, level string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(level)}
+	if strings.EqualFold(format, "json") {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// parseLevel converte o nome do nível; o padrão é info
+func parseLevel(level string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+	return l
+}
+
+// WithLogger retorna um contexto que carrega o logger informado
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext retorna o logger da requisição ou, fora de uma requisição, o padrão
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With acrescenta campos ao logger do contexto
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCounterVec_WritesSortedSeries(t *testing.T) {
+	c := &CounterVec{newVec("test_total", "Contador de teste", []string{"route", "status"})}
+	c.Inc("/b", "200")
+	c.Add(2, "/a", "500")
+	c.Inc("/a", `quote"d`)
+
+	var buf bytes.Buffer
+	c.write(&buf)
+
+	want := `# HELP test_total Contador de teste
+# TYPE test_total counter
+test_total{route="/a",status="500"} 2
+test_total{route="/a",status="quote\"d"} 1
+test_total{route="/b",status="200"} 1
+`
+	if buf.String() != want {
+		t.Errorf("saída incorreta:\n%s\nesperado:\n%s", buf.String(), want)
+	}
+}
+
+func TestHistogramVec_CumulativeBuckets(t *testing.T) {
+	h := &HistogramVec{vec: newVec("test_seconds", "Histograma de teste", []string{"route"}), buckets: []float64{0.1, 1}}
+	h.Observe(0.05, "/x")
+	h.Observe(0.5, "/x")
+	h.Observe(3, "/x")
+
+	var buf bytes.Buffer
+	h.write(&buf)
+
+	for _, line := range []string{
+		`test_seconds_bucket{route="/x",le="0.1"} 1`,
+		`test_seconds_bucket{route="/x",le="1"} 2`,
+		`test_seconds_bucket{route="/x",le="+Inf"} 3`,
+		`test_seconds_sum{route="/x"} 3.55`,
+		`test_seconds_count{route="/x"} 3`,
+	} {
+		if !strings.Contains(buf.String(), line+"\n") {
+			t.Errorf("linha ausente: %s\nsaída:\n%s", line, buf.String())
+		}
+	}
+}
+
+func TestHandler_ServesDefaultRegistry(t *testing.T) {
+	NewGaugeFunc("test_gauge", "Gauge de teste", func() []Sample {
+		return []Sample{{Labels: []string{"a"}, Value: 0.5}}
+	}, "name")
+
+	rr := httptest.NewRecorder()
+	Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+
+	if !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type incorreto: %s", rr.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rr.Body.String(), "# TYPE test_gauge gauge\ntest_gauge{name=\"a\"} 0.5\n") {
+		t.Errorf("gauge ausente:\n%s", rr.Body.String())
+	}
+}
@@ -1,19 +1,160 @@
 package middleware
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"github-api-demo/internal/logging"
+	"github-api-demo/internal/metrics"
+	"github-api-demo/internal/requestid"
+	"github-api-demo/internal/tracing"
+)
+
+// Métricas das requisições HTTP por rota e status, expostas em /metrics
+var (
+	httpRequests = metrics.NewCounterVec("http_requests_total",
+		"Requisições HTTP atendidas por rota, método e status", "route", "method", "status")
+	httpDuration = metrics.NewHistogramVec("http_request_duration_seconds",
+		"Duração das requisições HTTP por rota e status", nil, "route", "status")
 )
 
-// Logging middleware para registrar requisições HTTP
+// Logging middleware para registrar requisições HTTP com log estruturado.
+// Cria o logger da requisição (request_id definido por RequestID, método, rota) e o coloca no contexto,
+// para que serviços e clientes registrem com os mesmos campos. Ao final, registra
+// status, bytes, query, IP do cliente e duração.
 func Logging(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
-		log.Printf("📨 %s %s", r.Method, r.URL.Path)
-		next(w, r)
-		log.Printf("✅ %s %s - %v", r.Method, r.URL.Path, time.Since(start))
+		id := requestid.FromContext(r.Context())
+		if id == "" {
+			id = requestid.New()
+		}
+		logger := logging.FromContext(r.Context()).With(
+			"request_id", id,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+		if sc := tracing.SpanContextFromContext(r.Context()); sc.IsValid() {
+			logger = logger.With("trace_id", sc.TraceID.String())
+		}
+		
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+		next(rw, r.WithContext(logging.WithLogger(r.Context(), logger)))
+		
+		level := slog.LevelInfo
+		switch {
+		case rw.status >= 500:
+			level = slog.LevelError
+		case rw.status >= 400:
+			level = slog.LevelWarn
+		}
+		logger.Log(r.Context(), level, "requisição atendida",
+			"status", rw.status,
+			"bytes", rw.bytes,
+			"query", r.URL.RawQuery,
+			"client_ip", clientIP(r),
+			"duration_ms", time.Since(start).Milliseconds(),
+		)
+	}
+}
+
+// Metrics middleware para contar as requisições e medir sua duração. route é o
+// padrão registrado no roteador (ex: "/shows/"), não o caminho pedido, para que
+// o número de séries não cresça com IDs e URLs inexistentes.
+func Metrics(route string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+		next(rw, r)
+		
+		status := strconv.Itoa(rw.status)
+		httpRequests.Inc(route, metricMethod(r.Method), status)
+		httpDuration.Observe(time.Since(start).Seconds(), route, status)
+	}
+}
+
+// metricMethod limita o label method aos métodos HTTP conhecidos; qualquer
+// outro valor vira "other", já que o método vem livre do cliente.
+func metricMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	}
+	return "other"
+}
+
+// Tracing middleware para criar o span de servidor de cada requisição. Continua o
+// trace do cliente quando a requisição traz um header traceparent válido; route é
+// o padrão registrado no roteador, como em Metrics.
+func Tracing(route string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := tracing.Extract(r.Context(), r.Header)
+		ctx, span := tracing.Start(ctx, r.Method+" "+route, tracing.KindServer)
+		defer span.End()
+		span.SetAttributes(
+			"http.request.method", r.Method,
+			"http.route", route,
+			"url.path", r.URL.Path,
+		)
+
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+		next(rw, r.WithContext(ctx))
+
+		span.SetAttributes("http.response.status_code", rw.status)
+		if rw.status >= 500 {
+			span.SetStatus(tracing.StatusError, http.StatusText(rw.status))
+		}
+	}
+}
+
+// responseWriter guarda o status e o número de bytes escritos na resposta
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// Unwrap permite que http.ResponseController alcance o ResponseWriter original
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// clientIP retorna o IP do cliente, considerando os headers de proxy reverso
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		ip, _, _ := strings.Cut(forwarded, ",")
+		return strings.TrimSpace(ip)
+	}
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
 	}
+	return host
 }
 
 // CORS middleware para configurar headers de CORS
@@ -31,3 +172,27 @@ func CORS(next http.HandlerFunc) http.HandlerFunc {
 		next(w, r)
 	}
 }
+
+// Timeout define no contexto da requisição o prazo total para atendê-la; as chamadas
+// às APIs externas derivam seus prazos do tempo restante
+func Timeout(budget time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), budget)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestID aceita o X-Request-ID enviado pelo cliente (ou gera um novo, se
+// ausente ou inválido), guarda-o no contexto e o devolve na resposta
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+		w.Header().Set(requestid.Header, id)
+		w.Header().Set("Access-Control-Expose-Headers", requestid.Header)
+		next.ServeHTTP(w, r.WithContext(requestid.WithID(r.Context(), id)))
+	})
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github-api-demo/internal/logging"
+	"github-api-demo/internal/requestid"
+	"github-api-demo/internal/tracing"
+)
+
+func TestLogging_RecordsRequestFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, "json", "debug")
+
+	handler := Logging(func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).Debug("dentro do handler")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("nada aqui"))
+	})
+
+	req := httptest.NewRequest("GET", "/show?id=1", nil)
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	req.Header.Set(requestid.Header, "req-42")
+	req = req.WithContext(logging.WithLogger(req.Context(), logger))
+	RequestID(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+	var lines []map[string]interface{}
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var line map[string]interface{}
+		if err := dec.Decode(&line); err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("esperado 2 linhas de log, obtido %d", len(lines))
+	}
+
+	inner, done := lines[0], lines[1]
+	if inner["request_id"] != "req-42" || done["request_id"] != "req-42" {
+		t.Errorf("o log do handler deveria ter o mesmo request_id: %v %v", inner["request_id"], done["request_id"])
+	}
+	if done["level"] != slog.LevelWarn.String() || done["status"] != float64(404) || done["bytes"] != float64(9) {
+		t.Errorf("campos da resposta incorretos: %v", done)
+	}
+	if done["query"] != "id=1" || done["client_ip"] != "203.0.113.7" || done["path"] != "/show" {
+		t.Errorf("campos da requisição incorretos: %v", done)
+	}
+}
+
+func TestRequestID_AcceptsOrGenerates(t *testing.T) {
+	var seen string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = requestid.FromContext(r.Context())
+	}))
+
+	tests := []struct {
+		incoming string
+		keep     bool
+	}{
+		{"abc-123", true},
+		{"", false},
+		{"com espaço", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		if tt.incoming != "" {
+			req.Header.Set(requestid.Header, tt.incoming)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		echoed := rr.Header().Get(requestid.Header)
+		if echoed == "" || echoed != seen {
+			t.Errorf("%q: ID do contexto (%q) e da resposta (%q) deveriam ser iguais", tt.incoming, seen, echoed)
+		}
+		if (echoed == tt.incoming) != tt.keep {
+			t.Errorf("%q: ID devolvido inesperado: %q", tt.incoming, echoed)
+		}
+	}
+}
+
+func TestTracing_ContinuesInboundTrace(t *testing.T) {
+	var sc tracing.SpanContext
+	handler := Tracing("/show", func(w http.ResponseWriter, r *http.Request) {
+		sc = tracing.SpanContextFromContext(r.Context())
+	})
+
+	req := httptest.NewRequest("GET", "/show?id=1", nil)
+	req.Header.Set(tracing.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler(httptest.NewRecorder(), req)
+
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("o handler deveria continuar o trace recebido: %s", sc.TraceID)
+	}
+	if sc.SpanID.String() == "00f067aa0ba902b7" || !sc.SpanID.IsValid() {
+		t.Errorf("o handler deveria estar em um novo span: %s", sc.SpanID)
+	}
+}
+
+func TestMetrics_NormalizesMethodLabel(t *testing.T) {
+	handler := Metrics("/metrics-test", func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, method := range []string{"GET", "FOO", "BAR", "get"} {
+		handler(httptest.NewRecorder(), httptest.NewRequest(method, "/metrics-test", nil))
+	}
+
+	if got := httpRequests.Value("/metrics-test", "GET", "200"); got != 1 {
+		t.Errorf("esperada 1 requisição GET, obtido %v", got)
+	}
+	if got := httpRequests.Value("/metrics-test", "other", "200"); got != 3 {
+		t.Errorf("métodos desconhecidos deveriam ser agrupados em \"other\", obtido %v", got)
+	}
+	for _, method := range []string{"FOO", "BAR", "get"} {
+		if got := httpRequests.Value("/metrics-test", method, "200"); got != 0 {
+			t.Errorf("método %q não deveria virar série própria, obtido %v", method, got)
+		}
+	}
+}
@@ -2,8 +2,22 @@ package models
 
 // Response representa a resposta padrão da API
 type Response struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
-	Count   int         `json:"count,omitempty"`
+	Success         bool        `json:"success"`
+	Data            interface{} `json:"data,omitempty"`
+	Error           string      `json:"error,omitempty"`
+	Code            string      `json:"code,omitempty"`
+	RequestID       string      `json:"request_id,omitempty"`
+	Count           int         `json:"count,omitempty"`
+	Stale           bool        `json:"stale,omitempty"`
+	StaleAgeSeconds int         `json:"stale_age_seconds,omitempty"`
 }
+
+// Códigos estáveis do campo Code nas respostas de erro
+const (
+	CodeInvalidInput        = "invalid_input"
+	CodeNotFound            = "not_found"
+	CodeRateLimited         = "rate_limited"
+	CodeUpstreamError       = "upstream_error"
+	CodeUpstreamUnavailable = "upstream_unavailable"
+	CodeInternal            = "internal_error"
+)
@@ -15,16 +15,25 @@ type Episode struct {
 
 // Show representa um show de TV
 type Show struct {
-	ID        int      `json:"id"`
-	Name      string   `json:"name"`
-	Type      string   `json:"type"`
-	Language  string   `json:"language"`
-	Genres    []string `json:"genres"`
-	Status    string   `json:"status"`
-	Premiered string   `json:"premiered"`
-	Summary   string   `json:"summary"`
-	Image     *Image   `json:"image"`
-	Network   *Network `json:"network"`
+	ID         int         `json:"id"`
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	Language   string      `json:"language"`
+	Genres     []string    `json:"genres"`
+	Status     string      `json:"status"`
+	Premiered  string      `json:"premiered"`
+	Summary    string      `json:"summary"`
+	Image      *Image      `json:"image"`
+	Network    *Network    `json:"network"`
+	WebChannel *WebChannel `json:"webChannel"`
+	Externals  *Externals  `json:"externals,omitempty"`
+}
+
+// Externals representa os IDs do show em bases externas
+type Externals struct {
+	TVRage  int    `json:"tvrage"`
+	TheTVDB int    `json:"thetvdb"`
+	IMDB    string `json:"imdb"`
 }
 
 // Network representa a rede de TV
@@ -34,10 +43,18 @@ type Network struct {
 	Country Country `json:"country"`
 }
 
+// WebChannel representa um canal de streaming (Netflix, Prime Video...)
+type WebChannel struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Country *Country `json:"country"`
+}
+
 // Country representa o país
 type Country struct {
-	Name string `json:"name"`
-	Code string `json:"code"`
+	Name     string `json:"name"`
+	Code     string `json:"code"`
+	Timezone string `json:"timezone"`
 }
 
 // Image representa as imagens
@@ -48,9 +65,88 @@ type Image struct {
 
 // Schedule representa um item da programação
 type Schedule struct {
-	ID      int      `json:"id"`
-	Airdate string   `json:"airdate"`
-	Airtime string   `json:"airtime"`
-	Show    Show     `json:"show"`
-	Episode *Episode `json:"episode,omitempty"`
+	ID       int      `json:"id"`
+	Airdate  string   `json:"airdate"`
+	Airtime  string   `json:"airtime"`
+	Airstamp string   `json:"airstamp"`
+	Runtime  int      `json:"runtime"`
+	Show     Show     `json:"show"`
+	Episode  *Episode `json:"episode,omitempty"`
+}
+
+// UpcomingSchedule representa um item da programação que ainda vai começar
+type UpcomingSchedule struct {
+	Schedule
+	StartsInMinutes int    `json:"starts_in_minutes"`
+	EndsAt          string `json:"ends_at"`
+}
+
+// Season representa uma temporada de um show
+type Season struct {
+	ID           int      `json:"id"`
+	Number       int      `json:"number"`
+	Name         string   `json:"name"`
+	EpisodeOrder int      `json:"episodeOrder"`
+	PremiereDate string   `json:"premiereDate"`
+	EndDate      string   `json:"endDate"`
+	Network      *Network `json:"network"`
+	Image        *Image   `json:"image"`
+	Summary      string   `json:"summary"`
+}
+
+// Person representa uma pessoa (ator, roteirista, diretor...)
+type Person struct {
+	ID       int      `json:"id"`
+	Name     string   `json:"name"`
+	Country  *Country `json:"country"`
+	Birthday string   `json:"birthday"`
+	Deathday string   `json:"deathday"`
+	Gender   string   `json:"gender"`
+	Image    *Image   `json:"image"`
+}
+
+// Character representa um personagem interpretado no show
+type Character struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Image *Image `json:"image"`
+}
+
+// CastCredit representa um membro do elenco de um show
+type CastCredit struct {
+	Person    Person    `json:"person"`
+	Character Character `json:"character"`
+	Self      bool      `json:"self"`
+	Voice     bool      `json:"voice"`
+}
+
+// CrewCredit representa um membro da equipe técnica de um show
+type CrewCredit struct {
+	Type   string `json:"type"`
+	Person Person `json:"person"`
+}
+
+// SearchResult representa um resultado da busca de shows
+type SearchResult struct {
+	Score float64 `json:"score"`
+	Show  Show    `json:"show"`
+}
+
+// PersonSearchResult representa um resultado da busca de pessoas
+type PersonSearchResult struct {
+	Score  float64 `json:"score"`
+	Person Person  `json:"person"`
+}
+
+// PersonCastCredit representa uma participação de uma pessoa no elenco de um show
+type PersonCastCredit struct {
+	Self  bool `json:"self"`
+	Voice bool `json:"voice"`
+	Show  Show `json:"show"`
+}
+
+// PersonCrewCredit representa uma participação de uma pessoa na equipe técnica de um show
+type PersonCrewCredit struct {
+	Type string `json:"type"`
+	Show Show   `json:"show"`
 }
@@ -0,0 +1,50 @@
+// Package requestid gera e transporta no contexto o identificador de cada
+// requisição, usado para correlacionar logs, respostas de erro e chamadas às
+// APIs externas.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Header é o header HTTP que carrega o identificador da requisição
+const Header = "X-Request-ID"
+
+// maxLength limita o tamanho de um ID recebido do cliente
+const maxLength = 128
+
+type contextKey struct{}
+
+// New gera um identificador aleatório de 16 caracteres hexadecimais
+func New() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Valid indica se um ID recebido do cliente pode ser reaproveitado: não vazio,
+// com até 128 caracteres ASCII visíveis (sem espaços nem caracteres de controle)
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// WithID retorna um contexto que carrega o ID da requisição
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext retorna o ID da requisição, ou "" fora de uma requisição
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
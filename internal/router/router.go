@@ -4,25 +4,48 @@ import (
 	"net/http"
 
 	"github-api-demo/internal/handlers"
+	"github-api-demo/internal/metrics"
 	"github-api-demo/internal/middleware"
 )
 
 // Setup configura todas as rotas da aplicação
-func Setup(tvmazeHandler *handlers.TVMazeHandler, githubHandler *handlers.GitHubHandler) *http.ServeMux {
+func Setup(tvmazeHandler *handlers.TVMazeHandler, peopleHandler *handlers.PeopleHandler, githubHandler *handlers.GitHubHandler, diagnosticsHandler *handlers.DiagnosticsHandler) *http.ServeMux {
 	mux := http.NewServeMux()
 	
+	// handle registra a rota com tracing, log estruturado e métricas
+	handle := func(pattern string, handler http.HandlerFunc) {
+		mux.HandleFunc(pattern, middleware.Tracing(pattern, middleware.Logging(middleware.Metrics(pattern, handler))))
+	}
+	
 	// Rotas TVMaze
-	mux.HandleFunc("/", middleware.Logging(tvmazeHandler.Home))
-	mux.HandleFunc("/docs", middleware.Logging(handlers.DocsHandler))
-	mux.HandleFunc("/schedule", middleware.Logging(tvmazeHandler.Schedule))
-	mux.HandleFunc("/search", middleware.Logging(tvmazeHandler.Search))
-	mux.HandleFunc("/show", middleware.Logging(tvmazeHandler.ShowDetails))
-	mux.HandleFunc("/genre", middleware.Logging(tvmazeHandler.Genre))
-	mux.HandleFunc("/now", middleware.Logging(tvmazeHandler.NowPlaying))
+	handle("/", tvmazeHandler.Home)
+	handle("/docs", handlers.DocsHandler)
+	handle("/schedule", tvmazeHandler.Schedule)
+	handle("/schedule/web", tvmazeHandler.WebSchedule)
+	handle("/search", tvmazeHandler.Search)
+	handle("/show", tvmazeHandler.ShowDetails)
+	handle("/lookup", tvmazeHandler.Lookup)
+	handle("/show/cast", tvmazeHandler.ShowCast)
+	handle("/show/crew", tvmazeHandler.ShowCrew)
+	handle("/genre", tvmazeHandler.Genre)
+	handle("/now", tvmazeHandler.NowPlaying)
+	handle("/upcoming", tvmazeHandler.Upcoming)
+	handle("/shows/", tvmazeHandler.Shows)
+	handle("/seasons/", tvmazeHandler.SeasonEpisodes)
+	
+	// Rotas de pessoas
+	handle("/search/people", peopleHandler.Search)
+	handle("/people/", peopleHandler.People)
 	
 	// Rotas GitHub
-	mux.HandleFunc("/api/", middleware.Logging(githubHandler.Home))
-	mux.HandleFunc("/api/user", middleware.Logging(githubHandler.GetUser))
+	handle("/api/", githubHandler.Home)
+	handle("/api/user", githubHandler.GetUser)
+	
+	// Diagnóstico
+	handle("/diagnostics", diagnosticsHandler.Upstreams)
+	
+	// Métricas no formato do Prometheus (fora do log, para não registrar cada coleta)
+	mux.Handle("/metrics", metrics.Handler())
 	
 	return mux
 }
@@ -0,0 +1,112 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github-api-demo/internal/clients"
+	"github-api-demo/internal/fakemaze"
+	"github-api-demo/internal/handlers"
+	"github-api-demo/internal/middleware"
+	"github-api-demo/internal/models"
+	"github-api-demo/internal/services"
+)
+
+// newOfflineStack monta roteador, handlers, serviços e clientes como em cmd/api,
+// com as APIs externas respondidas pelo fakemaze. Sem retry, para que as falhas
+// injetadas cheguem à resposta.
+func newOfflineStack(t *testing.T, fake *fakemaze.Server) http.Handler {
+	t.Helper()
+	upstream := httptest.NewServer(fake)
+	t.Cleanup(upstream.Close)
+
+	noRetry := clients.RetryPolicy{MaxAttempts: 1}
+	tvmazeAPI := clients.NewTVMazeClient(clients.WithBaseURL(upstream.URL))
+	tvmazeAPI.SetRetryPolicy(noRetry)
+	tvmazeClient := clients.NewCachedTVMazeClient(tvmazeAPI, clients.DefaultCacheConfig())
+	githubClient := clients.NewGitHubClient(clients.WithBaseURL(upstream.URL))
+	githubClient.SetRetryPolicy(noRetry)
+
+	tvmazeService := services.NewTVMazeService(tvmazeClient)
+	mux := Setup(
+		handlers.NewTVMazeHandler(tvmazeService),
+		handlers.NewPeopleHandler(services.NewPeopleService(tvmazeClient)),
+		handlers.NewGitHubHandler(services.NewGitHubService(githubClient)),
+		handlers.NewDiagnosticsHandler(tvmazeAPI, githubClient),
+	)
+	return middleware.RequestID(middleware.Timeout(5*time.Second, mux))
+}
+
+// get faz a requisição ao roteador e decodifica a resposta padrão
+func get(t *testing.T, h http.Handler, target string) (*httptest.ResponseRecorder, models.Response) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", target, nil))
+
+	var body models.Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("%s: resposta não é JSON: %v\n%s", target, err, rec.Body)
+	}
+	return rec, body
+}
+
+func TestRouter_ServesFromFakemaze(t *testing.T) {
+	h := newOfflineStack(t, fakemaze.New(fakemaze.Config{}))
+
+	tests := []struct {
+		target string
+		count  int
+	}{
+		{"/schedule?country=US&date=2024-03-01", 4},
+		{"/schedule?country=US&date=2024-03-01&source=all", 6},
+		{"/search?q=thrones", 1},
+		{"/show?id=431", 0},
+		{"/api/user?username=octocat", 0},
+	}
+	for _, tt := range tests {
+		rec, body := get(t, h, tt.target)
+		if rec.Code != http.StatusOK || !body.Success || body.Count != tt.count {
+			t.Errorf("%s: status %d, count %d, esperado 200 e %d: %s", tt.target, rec.Code, body.Count, tt.count, rec.Body)
+		}
+		if rec.Header().Get("X-Request-ID") == "" {
+			t.Errorf("%s: resposta sem X-Request-ID", tt.target)
+		}
+	}
+
+	rec, body := get(t, h, "/api/user?username=ninguem-aqui")
+	if rec.Code != http.StatusNotFound || body.Code != models.CodeNotFound {
+		t.Errorf("usuário inexistente: status %d, code %q", rec.Code, body.Code)
+	}
+}
+
+func TestRouter_SurfacesInjectedFailures(t *testing.T) {
+	fake := fakemaze.New(fakemaze.Config{RetryAfter: 2 * time.Second})
+	h := newOfflineStack(t, fake)
+
+	fake.FailNext(http.StatusTooManyRequests, 1)
+	rec, body := get(t, h, "/show?id=82")
+	if rec.Code != http.StatusTooManyRequests || body.Code != models.CodeRateLimited || rec.Header().Get("Retry-After") != "2" {
+		t.Errorf("429 do TVMaze: status %d, code %q, Retry-After %q", rec.Code, body.Code, rec.Header().Get("Retry-After"))
+	}
+
+	fake.FailNext(http.StatusServiceUnavailable, 1)
+	rec, body = get(t, h, "/search?q=office")
+	if rec.Code != http.StatusServiceUnavailable || body.Code != models.CodeUpstreamUnavailable {
+		t.Errorf("503 do TVMaze: status %d, code %q", rec.Code, body.Code)
+	}
+
+	fake.FailNext(http.StatusInternalServerError, 1)
+	rec, body = get(t, h, "/api/user?username=octocat")
+	if rec.Code != http.StatusServiceUnavailable || body.Code != models.CodeUpstreamUnavailable {
+		t.Errorf("500 do GitHub: status %d, code %q", rec.Code, body.Code)
+	}
+
+	// Sem falhas pendentes, a mesma rota volta a responder
+	rec, _ = get(t, h, "/show?id=82")
+	if rec.Code != http.StatusOK {
+		t.Errorf("após as falhas injetadas: status %d", rec.Code)
+	}
+}
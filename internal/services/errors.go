@@ -0,0 +1,50 @@
+package services
+
+import (
+	"fmt"
+
+	"github-api-demo/internal/clients"
+)
+
+// Categorias de erro expostas pelos serviços, comparáveis com errors.Is.
+// São as mesmas dos clientes, para que erros das APIs externas atravessem
+// a camada de serviço sem conversão.
+var (
+	ErrInvalidInput        = clients.ErrInvalidInput
+	ErrNotFound            = clients.ErrNotFound
+	ErrRateLimited         = clients.ErrRateLimited
+	ErrUpstreamUnavailable = clients.ErrUpstreamUnavailable
+	ErrUpstream            = clients.ErrUpstream
+)
+
+// invalidInput cria um erro de entrada inválida com a mensagem formatada
+func invalidInput(format string, args ...interface{}) error {
+	return clients.NewError(ErrInvalidInput, fmt.Sprintf(format, args...))
+}
+
+// checkID valida um ID numérico do TVMaze antes que ele vá para o caminho da
+// requisição, onde caracteres como '?' ou '/' mudariam a URL chamada
+func checkID(id string) error {
+	if id == "" {
+		return invalidInput("ID não pode ser vazio")
+	}
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return invalidInput("ID deve ser numérico: %s", id)
+		}
+	}
+	return nil
+}
+
+// checkCountry valida o código ISO de 2 letras do país antes que ele vá para a
+// query da requisição, onde '&' ou '#' mudariam a URL chamada e a chave do cache
+func checkCountry(country string) error {
+	if len(country) != 2 || !isLetter(country[0]) || !isLetter(country[1]) {
+		return invalidInput("país deve ser um código ISO de 2 letras: %s", country)
+	}
+	return nil
+}
+
+func isLetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
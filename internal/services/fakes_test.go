@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github-api-demo/internal/clients"
+	"github-api-demo/internal/models"
+)
+
+// fakeTVMaze é uma TVMazeAPI em memória: devolve a programação cadastrada para
+// cada data (TV em schedules, streaming em webSchedules) e registra as chamadas,
+// que podem ser concorrentes. Com err definido (no literal ou por fail), todas as
+// chamadas falham. Métodos não implementados causam panic.
+type fakeTVMaze struct {
+	clients.TVMazeAPI
+	schedules    map[string][]models.Schedule
+	webSchedules map[string][]models.Schedule
+	err          error
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (f *fakeTVMaze) GetSchedule(ctx context.Context, country, date string) ([]models.Schedule, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, country+":"+date)
+	err := f.err
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return f.schedules[date], nil
+}
+
+func (f *fakeTVMaze) GetWebSchedule(ctx context.Context, country, date string) ([]models.Schedule, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, "web:"+country+":"+date)
+	err := f.err
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return f.webSchedules[date], nil
+}
+
+// fail faz as próximas chamadas retornarem err; nil volta a responder normalmente
+func (f *fakeTVMaze) fail(err error) {
+	f.mu.Lock()
+	f.err = err
+	f.mu.Unlock()
+}
+
+// fakeGitHub é uma GitHubAPI em memória com os usuários cadastrados
+type fakeGitHub struct {
+	users map[string]*models.GitHubUser
+}
+
+func (f *fakeGitHub) GetUser(ctx context.Context, username string) (*models.GitHubUser, error) {
+	user, ok := f.users[username]
+	if !ok {
+		return nil, clients.NewError(ErrNotFound, "usuário não encontrado")
+	}
+	return user, nil
+}
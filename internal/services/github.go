@@ -1,28 +1,32 @@
 package services
 
 import (
-	"fmt"
+	"context"
 
 	"github-api-demo/internal/clients"
 	"github-api-demo/internal/models"
+	"github-api-demo/internal/tracing"
 )
 
 // GitHubService contém a lógica de negócio para o GitHub
 type GitHubService struct {
-	client *clients.GitHubClient
+	client clients.GitHubAPI
 }
 
 // NewGitHubService cria uma nova instância do serviço
-func NewGitHubService(client *clients.GitHubClient) *GitHubService {
+func NewGitHubService(client clients.GitHubAPI) *GitHubService {
 	return &GitHubService{
 		client: client,
 	}
 }
 
 // GetUser retorna dados de um usuário do GitHub
-func (s *GitHubService) GetUser(username string) (*models.GitHubUser, error) {
+func (s *GitHubService) GetUser(ctx context.Context, username string) (*models.GitHubUser, error) {
+	ctx, span := tracing.Start(ctx, "GitHubService.GetUser", tracing.KindInternal)
+	defer span.End()
+
 	if username == "" {
-		return nil, fmt.Errorf("username não pode ser vazio")
+		return nil, invalidInput("username não pode ser vazio")
 	}
-	return s.client.GetUser(username)
+	return s.client.GetUser(ctx, username)
 }
@@ -1,9 +1,12 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github-api-demo/internal/clients"
+	"github-api-demo/internal/models"
 )
 
 func TestNewGitHubService(t *testing.T) {
@@ -23,7 +26,7 @@ func TestGetUser_EmptyUsername(t *testing.T) {
 	client := clients.NewGitHubClient()
 	service := NewGitHubService(client)
 	
-	_, err := service.GetUser("")
+	_, err := service.GetUser(context.Background(), "")
 	if err == nil {
 		t.Error("GetUser deve retornar erro para username vazio")
 	}
@@ -32,3 +35,22 @@ func TestGetUser_EmptyUsername(t *testing.T) {
 		t.Errorf("Mensagem de erro incorreta: %v", err)
 	}
 }
+
+func TestGetUser_UsesClient(t *testing.T) {
+	client := &fakeGitHub{users: map[string]*models.GitHubUser{
+		"octocat": {Login: "octocat", Name: "The Octocat"},
+	}}
+	service := NewGitHubService(client)
+
+	user, err := service.GetUser(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if user.Name != "The Octocat" {
+		t.Errorf("usuário incorreto: %+v", user)
+	}
+
+	if _, err := service.GetUser(context.Background(), "ninguem"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("esperado erro de não encontrado, obtido %v", err)
+	}
+}
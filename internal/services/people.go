@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+
+	"github-api-demo/internal/clients"
+	"github-api-demo/internal/models"
+	"github-api-demo/internal/tracing"
+)
+
+// PeopleService contém a lógica de negócio para pessoas (atores, equipe técnica)
+type PeopleService struct {
+	client clients.TVMazeAPI
+}
+
+// NewPeopleService cria uma nova instância do serviço
+func NewPeopleService(client clients.TVMazeAPI) *PeopleService {
+	return &PeopleService{
+		client: client,
+	}
+}
+
+// ForRequest retorna uma cópia do serviço que registra em meta o uso de cache,
+// quando o cliente é um CachedTVMazeClient
+func (s *PeopleService) ForRequest(meta *RequestMeta) *PeopleService {
+	cached, ok := s.client.(*clients.CachedTVMazeClient)
+	if !ok {
+		return s
+	}
+	return &PeopleService{client: cached.WithRecorder(&meta.Cache)}
+}
+
+// Search busca pessoas pelo nome
+func (s *PeopleService) Search(ctx context.Context, query string) ([]models.PersonSearchResult, error) {
+	ctx, span := tracing.Start(ctx, "PeopleService.Search", tracing.KindInternal)
+	defer span.End()
+
+	if query == "" {
+		return nil, invalidInput("query não pode ser vazia")
+	}
+	return s.client.SearchPeople(ctx, query)
+}
+
+// GetPerson retorna os dados de uma pessoa
+func (s *PeopleService) GetPerson(ctx context.Context, id string) (*models.Person, error) {
+	ctx, span := tracing.Start(ctx, "PeopleService.GetPerson", tracing.KindInternal)
+	defer span.End()
+
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return s.client.GetPerson(ctx, id)
+}
+
+// GetCastCredits retorna os shows em que a pessoa atuou
+func (s *PeopleService) GetCastCredits(ctx context.Context, id string) ([]models.PersonCastCredit, error) {
+	ctx, span := tracing.Start(ctx, "PeopleService.GetCastCredits", tracing.KindInternal)
+	defer span.End()
+
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return s.client.GetPersonCastCredits(ctx, id)
+}
+
+// GetCrewCredits retorna os shows em que a pessoa fez parte da equipe técnica
+func (s *PeopleService) GetCrewCredits(ctx context.Context, id string) ([]models.PersonCrewCredit, error) {
+	ctx, span := tracing.Start(ctx, "PeopleService.GetCrewCredits", tracing.KindInternal)
+	defer span.End()
+
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return s.client.GetPersonCrewCredits(ctx, id)
+}
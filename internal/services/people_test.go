@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github-api-demo/internal/clients"
+)
+
+func TestNewPeopleService(t *testing.T) {
+	client := clients.NewTVMazeClient()
+	service := NewPeopleService(client)
+
+	if service == nil {
+		t.Error("NewPeopleService deve retornar uma instância válida")
+	}
+
+	if service.client == nil {
+		t.Error("Cliente não pode ser nil")
+	}
+}
+
+func TestPeopleSearch_EmptyQuery(t *testing.T) {
+	client := clients.NewTVMazeClient()
+	service := NewPeopleService(client)
+
+	_, err := service.Search(context.Background(), "")
+	if err == nil {
+		t.Error("Search deve retornar erro para query vazia")
+	}
+
+	if err.Error() != "query não pode ser vazia" {
+		t.Errorf("Mensagem de erro incorreta: %v", err)
+	}
+}
+
+func TestPeopleCredits_EmptyID(t *testing.T) {
+	client := clients.NewTVMazeClient()
+	service := NewPeopleService(client)
+
+	if _, err := service.GetPerson(context.Background(), ""); err == nil || err.Error() != "ID não pode ser vazio" {
+		t.Errorf("GetPerson deve retornar erro para ID vazio: %v", err)
+	}
+
+	if _, err := service.GetCastCredits(context.Background(), ""); err == nil || err.Error() != "ID não pode ser vazio" {
+		t.Errorf("GetCastCredits deve retornar erro para ID vazio: %v", err)
+	}
+
+	if _, err := service.GetCrewCredits(context.Background(), ""); err == nil || err.Error() != "ID não pode ser vazio" {
+		t.Errorf("GetCrewCredits deve retornar erro para ID vazio: %v", err)
+	}
+
+	if _, err := service.GetCastCredits(context.Background(), "1/crewcredits?embed=show#"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetCastCredits deve rejeitar ID não numérico: %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github-api-demo/internal/clients"
+)
+
+// RequestMeta acumula informações sobre como uma requisição foi atendida:
+// uso do cache e dados servidos do estoque de última versão válida
+type RequestMeta struct {
+	Cache clients.CacheRecorder
+
+	mu       sync.Mutex
+	stale    bool
+	staleAge time.Duration
+}
+
+// markStale registra que parte da resposta veio do estoque, guardando a maior idade
+func (m *RequestMeta) markStale(age time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stale = true
+	if age > m.staleAge {
+		m.staleAge = age
+	}
+}
+
+// Stale indica se a resposta usa dados do estoque e a idade do dado mais antigo
+func (m *RequestMeta) Stale() (bool, time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stale, m.staleAge
+}
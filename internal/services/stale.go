@@ -0,0 +1,59 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// StaleStore guarda a última resposta válida de cada consulta, para ser servida
+// quando a API externa falhar, desde que não seja mais antiga que maxAge
+type StaleStore struct {
+	mu      sync.Mutex
+	maxAge  time.Duration
+	entries map[string]staleEntry
+	now     func() time.Time
+}
+
+type staleEntry struct {
+	value    interface{}
+	storedAt time.Time
+}
+
+// NewStaleStore cria um estoque que aceita servir dados com até maxAge de idade
+func NewStaleStore(maxAge time.Duration) *StaleStore {
+	return &StaleStore{
+		maxAge:  maxAge,
+		entries: make(map[string]staleEntry),
+		now:     time.Now,
+	}
+}
+
+// put armazena o valor e descarta entradas mais antigas que maxAge
+func (st *StaleStore) put(key string, value interface{}) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	now := st.now()
+	for k, e := range st.entries {
+		if now.Sub(e.storedAt) > st.maxAge {
+			delete(st.entries, k)
+		}
+	}
+	st.entries[key] = staleEntry{value: value, storedAt: now}
+}
+
+// get retorna o último valor válido e sua idade, se ainda estiver dentro de maxAge
+func (st *StaleStore) get(key string) (interface{}, time.Duration, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	e, ok := st.entries[key]
+	if !ok {
+		return nil, 0, false
+	}
+	age := st.now().Sub(e.storedAt)
+	if age > st.maxAge {
+		return nil, 0, false
+	}
+	return e.value, age, true
+}
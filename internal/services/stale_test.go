@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github-api-demo/internal/models"
+)
+
+func TestStaleStore_ServesLastKnownGood(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	store := NewStaleStore(time.Hour)
+	store.now = func() time.Time { return now }
+
+	client := &fakeTVMaze{schedules: map[string][]models.Schedule{
+		"2024-01-10": {{ID: 1, Airdate: "2024-01-10"}},
+	}}
+	service := NewTVMazeService(client)
+	service.SetStaleStore(store)
+
+	day := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	if _, err := service.GetScheduleRange(context.Background(), "US", SourceTV, day, day); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	client.fail(errors.New("status code: 503"))
+	now = now.Add(10 * time.Minute)
+
+	meta := &RequestMeta{}
+	schedule, err := service.ForRequest(meta).GetScheduleRange(context.Background(), "US", SourceTV, day, day)
+	if err != nil {
+		t.Fatalf("deveria servir a última programação válida: %v", err)
+	}
+	if len(schedule) != 1 {
+		t.Errorf("esperado 1 item, obtido %d", len(schedule))
+	}
+	if stale, age := meta.Stale(); !stale || age != 10*time.Minute {
+		t.Errorf("resposta deveria ser marcada como stale com 10m, obtido %v %v", stale, age)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := service.GetScheduleRange(context.Background(), "US", SourceTV, day, day); err == nil {
+		t.Error("dados mais antigos que a idade máxima não devem ser servidos")
+	}
+}
@@ -1,55 +1,348 @@
 package services
 
 import (
-	"fmt"
+	"context"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github-api-demo/internal/clients"
+	"github-api-demo/internal/logging"
 	"github-api-demo/internal/models"
+	"github-api-demo/internal/tracing"
 )
 
+// Clock retorna o instante atual. Permite fixar o tempo em testes e prévias.
+type Clock func() time.Time
+
 // TVMazeService contém a lógica de negócio para o TVMaze
 type TVMazeService struct {
-	client *clients.TVMazeClient
+	client clients.TVMazeAPI
+	stale  *StaleStore
+	meta   *RequestMeta
+	clock  Clock
 }
 
 // NewTVMazeService cria uma nova instância do serviço
-func NewTVMazeService(client *clients.TVMazeClient) *TVMazeService {
+func NewTVMazeService(client clients.TVMazeAPI) *TVMazeService {
 	return &TVMazeService{
 		client: client,
+		clock:  time.Now,
 	}
 }
 
+// SetClock substitui o relógio usado para "hoje" e "agora"
+func (s *TVMazeService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// Now retorna o instante atual segundo o relógio do serviço
+func (s *TVMazeService) Now() time.Time {
+	return s.clock()
+}
+
+// SetStaleStore ativa o uso da última programação válida quando a API do TVMaze falhar
+func (s *TVMazeService) SetStaleStore(store *StaleStore) {
+	s.stale = store
+}
+
+// ForRequest retorna uma cópia do serviço que registra em meta o uso de cache
+// (quando o cliente é um CachedTVMazeClient) e de dados do estoque
+func (s *TVMazeService) ForRequest(meta *RequestMeta) *TVMazeService {
+	clone := *s
+	clone.meta = meta
+	if cached, ok := s.client.(*clients.CachedTVMazeClient); ok {
+		clone.client = cached.WithRecorder(&meta.Cache)
+	}
+	return &clone
+}
+
 // GetTodaySchedule retorna a programação de hoje para um país
-func (s *TVMazeService) GetTodaySchedule(country string) ([]models.Schedule, error) {
-	today := time.Now().Format("2006-01-02")
-	return s.client.GetSchedule(country, today)
+func (s *TVMazeService) GetTodaySchedule(ctx context.Context, country string) ([]models.Schedule, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.GetTodaySchedule", tracing.KindInternal)
+	defer span.End()
+
+	today := s.Now().Format("2006-01-02")
+	return s.fetchSchedule(ctx, country, SourceTV, today)
+}
+
+// ScheduleSource indica de onde vem a programação: TV aberta/cabo, streaming ou ambos
+type ScheduleSource string
+
+const (
+	SourceTV  ScheduleSource = "tv"
+	SourceWeb ScheduleSource = "web"
+	SourceAll ScheduleSource = "all"
+)
+
+// MaxScheduleDays é o número máximo de dias aceito em uma consulta de programação por período
+const MaxScheduleDays = 14
+
+// GetScheduleRange retorna a programação de um país entre duas datas (inclusive),
+// buscando os dias em paralelo e ordenando o resultado por data e horário
+func (s *TVMazeService) GetScheduleRange(ctx context.Context, country string, source ScheduleSource, from, to time.Time) ([]models.Schedule, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.GetScheduleRange", tracing.KindInternal)
+	defer span.End()
+
+	if source != SourceTV && source != SourceWeb && source != SourceAll {
+		return nil, invalidInput("fonte inválida: %s", source)
+	}
+	
+	if to.Before(from) {
+		return nil, invalidInput("data final não pode ser anterior à inicial")
+	}
+	
+	// O limite é verificado antes de montar a lista, que seria enorme para datas distantes
+	if to.Sub(from) > (MaxScheduleDays-1)*24*time.Hour {
+		return nil, invalidInput("período máximo é de %d dias", MaxScheduleDays)
+	}
+	
+	var dates []string
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d.Format("2006-01-02"))
+	}
+	
+	results := make([][]models.Schedule, len(dates))
+	errs := make([]error, len(dates))
+	
+	var wg sync.WaitGroup
+	for i, date := range dates {
+		wg.Add(1)
+		go func(i int, date string) {
+			defer wg.Done()
+			results[i], errs[i] = s.fetchSchedule(ctx, country, source, date)
+		}(i, date)
+	}
+	wg.Wait()
+	
+	var merged []models.Schedule
+	for i := range dates {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		merged = append(merged, results[i]...)
+	}
+	
+	sortSchedule(merged)
+	return merged, nil
+}
+
+// fetchSchedule busca a programação de uma data; se a API falhar e houver um
+// estoque configurado, serve a última versão válida e marca a requisição como stale
+func (s *TVMazeService) fetchSchedule(ctx context.Context, country string, source ScheduleSource, date string) ([]models.Schedule, error) {
+	if err := checkCountry(country); err != nil {
+		return nil, err
+	}
+	
+	schedule, err := s.getScheduleBySource(ctx, country, source, date)
+	if s.stale == nil {
+		return schedule, err
+	}
+	
+	key := string(source) + ":" + country + ":" + date
+	if err == nil {
+		s.stale.put(key, schedule)
+		return schedule, nil
+	}
+	
+	if value, age, ok := s.stale.get(key); ok {
+		logging.FromContext(ctx).Warn("servindo programação antiga após falha do TVMaze",
+			"key", key, "stale_age_seconds", int(age.Seconds()), "error", err)
+		s.meta.markStale(age)
+		return value.([]models.Schedule), nil
+	}
+	return nil, err
 }
 
-// SearchShows busca shows pelo nome
-func (s *TVMazeService) SearchShows(query string) ([]map[string]interface{}, error) {
+// getScheduleBySource busca a programação de uma data na fonte indicada
+func (s *TVMazeService) getScheduleBySource(ctx context.Context, country string, source ScheduleSource, date string) ([]models.Schedule, error) {
+	switch source {
+	case SourceTV:
+		return s.client.GetSchedule(ctx, country, date)
+	case SourceWeb:
+		return s.client.GetWebSchedule(ctx, country, date)
+	}
+	
+	tv, err := s.client.GetSchedule(ctx, country, date)
+	if err != nil {
+		return nil, err
+	}
+	web, err := s.client.GetWebSchedule(ctx, country, date)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Nova slice: os resultados do cliente podem vir compartilhados do cache
+	merged := make([]models.Schedule, 0, len(tv)+len(web))
+	merged = append(merged, tv...)
+	return append(merged, web...), nil
+}
+
+// sortSchedule ordena a programação por data e horário de exibição
+func sortSchedule(schedule []models.Schedule) {
+	sort.SliceStable(schedule, func(i, j int) bool {
+		if schedule[i].Airdate != schedule[j].Airdate {
+			return schedule[i].Airdate < schedule[j].Airdate
+		}
+		return schedule[i].Airtime < schedule[j].Airtime
+	})
+}
+
+// SearchFilter define os filtros aplicados aos resultados da busca de shows
+type SearchFilter struct {
+	MinScore float64
+	Limit    int
+	Genre    string
+	Language string
+}
+
+// SearchShows busca shows pelo nome e aplica os filtros informados
+func (s *TVMazeService) SearchShows(ctx context.Context, query string, filter SearchFilter) ([]models.SearchResult, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.SearchShows", tracing.KindInternal)
+	defer span.End()
+
 	if query == "" {
-		return nil, fmt.Errorf("query não pode ser vazia")
+		return nil, invalidInput("query não pode ser vazia")
+	}
+	
+	results, err := s.client.SearchShows(ctx, query)
+	if err != nil {
+		return nil, err
 	}
-	return s.client.SearchShows(query)
+	
+	return filterSearchResults(results, filter), nil
+}
+
+// filterSearchResults aplica score mínimo, gênero, idioma e limite aos resultados
+func filterSearchResults(results []models.SearchResult, filter SearchFilter) []models.SearchResult {
+	genreLower := strings.ToLower(filter.Genre)
+	
+	filtered := make([]models.SearchResult, 0, len(results))
+	for _, result := range results {
+		if result.Score < filter.MinScore {
+			continue
+		}
+		if filter.Language != "" && !strings.EqualFold(result.Show.Language, filter.Language) {
+			continue
+		}
+		if genreLower != "" && !hasGenre(result.Show, genreLower) {
+			continue
+		}
+		filtered = append(filtered, result)
+		if filter.Limit > 0 && len(filtered) == filter.Limit {
+			break
+		}
+	}
+	
+	return filtered
+}
+
+// hasGenre verifica se o show pertence ao gênero (já em minúsculas)
+func hasGenre(show models.Show, genreLower string) bool {
+	for _, g := range show.Genres {
+		if strings.Contains(strings.ToLower(g), genreLower) {
+			return true
+		}
+	}
+	return false
 }
 
 // GetShowByID retorna os detalhes de um show
-func (s *TVMazeService) GetShowByID(id string) (*models.Show, error) {
+func (s *TVMazeService) GetShowByID(ctx context.Context, id string) (*models.Show, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.GetShowByID", tracing.KindInternal)
+	defer span.End()
+
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return s.client.GetShowByID(ctx, id)
+}
+
+// LookupSources lista as bases externas aceitas por LookupShow
+var LookupSources = []string{"imdb", "thetvdb", "tvrage"}
+
+// LookupShow retorna um show a partir do seu ID em uma base externa
+func (s *TVMazeService) LookupShow(ctx context.Context, source, id string) (*models.Show, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.LookupShow", tracing.KindInternal)
+	defer span.End()
+
 	if id == "" {
-		return nil, fmt.Errorf("ID não pode ser vazio")
+		return nil, invalidInput("ID não pode ser vazio")
+	}
+	for _, valid := range LookupSources {
+		if source == valid {
+			return s.client.LookupShow(ctx, source, id)
+		}
+	}
+	return nil, invalidInput("fonte inválida: %s", source)
+}
+
+// GetEpisodes retorna a lista de episódios de um show
+func (s *TVMazeService) GetEpisodes(ctx context.Context, showID string) ([]models.Episode, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.GetEpisodes", tracing.KindInternal)
+	defer span.End()
+
+	if err := checkID(showID); err != nil {
+		return nil, err
+	}
+	return s.client.GetEpisodes(ctx, showID)
+}
+
+// GetSeasons retorna as temporadas de um show
+func (s *TVMazeService) GetSeasons(ctx context.Context, showID string) ([]models.Season, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.GetSeasons", tracing.KindInternal)
+	defer span.End()
+
+	if err := checkID(showID); err != nil {
+		return nil, err
+	}
+	return s.client.GetSeasons(ctx, showID)
+}
+
+// GetSeasonEpisodes retorna os episódios de uma temporada
+func (s *TVMazeService) GetSeasonEpisodes(ctx context.Context, seasonID string) ([]models.Episode, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.GetSeasonEpisodes", tracing.KindInternal)
+	defer span.End()
+
+	if err := checkID(seasonID); err != nil {
+		return nil, err
 	}
-	return s.client.GetShowByID(id)
+	return s.client.GetSeasonEpisodes(ctx, seasonID)
+}
+
+// GetCast retorna o elenco de um show
+func (s *TVMazeService) GetCast(ctx context.Context, showID string) ([]models.CastCredit, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.GetCast", tracing.KindInternal)
+	defer span.End()
+
+	if err := checkID(showID); err != nil {
+		return nil, err
+	}
+	return s.client.GetCast(ctx, showID)
+}
+
+// GetCrew retorna a equipe técnica de um show
+func (s *TVMazeService) GetCrew(ctx context.Context, showID string) ([]models.CrewCredit, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.GetCrew", tracing.KindInternal)
+	defer span.End()
+
+	if err := checkID(showID); err != nil {
+		return nil, err
+	}
+	return s.client.GetCrew(ctx, showID)
 }
 
 // GetScheduleByGenre retorna a programação filtrada por gênero
-func (s *TVMazeService) GetScheduleByGenre(country, genre string) ([]models.Schedule, error) {
+func (s *TVMazeService) GetScheduleByGenre(ctx context.Context, country, genre string) ([]models.Schedule, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.GetScheduleByGenre", tracing.KindInternal)
+	defer span.End()
+
 	if genre == "" {
-		return nil, fmt.Errorf("gênero não pode ser vazio")
+		return nil, invalidInput("gênero não pode ser vazio")
 	}
 
-	schedule, err := s.GetTodaySchedule(country)
+	schedule, err := s.GetTodaySchedule(ctx, country)
 	if err != nil {
 		return nil, err
 	}
@@ -59,54 +352,196 @@ func (s *TVMazeService) GetScheduleByGenre(country, genre string) ([]models.Sche
 	genreLower := strings.ToLower(genre)
 	
 	for _, item := range schedule {
-		for _, g := range item.Show.Genres {
-			if strings.ToLower(g) == genreLower || strings.Contains(strings.ToLower(g), genreLower) {
-				filtered = append(filtered, item)
-				break
-			}
+		if hasGenre(item.Show, genreLower) {
+			filtered = append(filtered, item)
 		}
 	}
 
 	return filtered, nil
 }
 
+// defaultRuntime é a duração assumida quando a TVMaze não informa o runtime do episódio
+const defaultRuntime = 60 * time.Minute
+
 // GetNowPlaying retorna os programas que estão passando agora
-func (s *TVMazeService) GetNowPlaying(country string) ([]models.Schedule, error) {
-	schedule, err := s.GetTodaySchedule(country)
+func (s *TVMazeService) GetNowPlaying(ctx context.Context, country string) ([]models.Schedule, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.GetNowPlaying", tracing.KindInternal)
+	defer span.End()
+
+	return s.GetPlayingAt(ctx, country, s.Now())
+}
+
+// GetPlayingAt retorna os programas que estão no ar no instante informado
+func (s *TVMazeService) GetPlayingAt(ctx context.Context, country string, at time.Time) ([]models.Schedule, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.GetPlayingAt", tracing.KindInternal)
+	defer span.End()
+
+	schedule, err := s.scheduleAround(ctx, country, at, at)
 	if err != nil {
 		return nil, err
 	}
+	
+	return playingAt(schedule, at), nil
+}
+
+// MaxUpcomingWindow é a maior janela aceita por GetUpcoming
+const MaxUpcomingWindow = 24 * time.Hour
+
+// GetUpcoming retorna os episódios que começam dentro da janela informada,
+// ordenados pelo horário de início
+func (s *TVMazeService) GetUpcoming(ctx context.Context, country string, within time.Duration) ([]models.UpcomingSchedule, error) {
+	ctx, span := tracing.Start(ctx, "TVMazeService.GetUpcoming", tracing.KindInternal)
+	defer span.End()
 
-	now := time.Now()
-	currentTime := now.Format("15:04")
+	if within < time.Minute || within > MaxUpcomingWindow {
+		return nil, invalidInput("janela deve estar entre 1 minuto e %v", MaxUpcomingWindow)
+	}
+	
+	now := s.Now()
 	
-	var nowPlaying []models.Schedule
+	schedule, err := s.scheduleAround(ctx, country, now, now.Add(within))
+	if err != nil {
+		return nil, err
+	}
 	
+	return upcomingAt(schedule, now, within), nil
+}
+
+// scheduleAround busca a programação que pode estar no ar entre from e to.
+// Inclui um dia (UTC) antes e depois para cobrir qualquer fuso do país e
+// programas que atravessam a meia-noite.
+func (s *TVMazeService) scheduleAround(ctx context.Context, country string, from, to time.Time) ([]models.Schedule, error) {
+	from, to = from.UTC(), to.UTC()
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	return s.GetScheduleRange(ctx, country, SourceTV, first, last)
+}
+
+// playingAt retorna os itens da programação que estão no ar no instante informado
+func playingAt(schedule []models.Schedule, at time.Time) []models.Schedule {
+	var playing []models.Schedule
 	for _, item := range schedule {
-		if item.Airtime == "" {
-			continue
+		start, end, ok := airingWindow(item)
+		if ok && !at.Before(start) && at.Before(end) {
+			playing = append(playing, item)
 		}
-		
-		// Parse do horário do programa
-		airtime, err := time.Parse("15:04", item.Airtime)
-		if err != nil {
+	}
+	return playing
+}
+
+// upcomingAt retorna os itens que começam em (at, at+within], ordenados pelo início
+func upcomingAt(schedule []models.Schedule, at time.Time, within time.Duration) []models.UpcomingSchedule {
+	type startingItem struct {
+		item  models.UpcomingSchedule
+		start time.Time
+	}
+	
+	var starting []startingItem
+	limit := at.Add(within)
+	for _, item := range schedule {
+		start, end, ok := airingWindow(item)
+		if !ok || !start.After(at) || start.After(limit) {
 			continue
 		}
-		
-		// Calcular horário de fim (airtime + runtime)
-		runtime := 60 // runtime padrão de 60 minutos
-		if item.Episode != nil && item.Episode.Runtime > 0 {
-			runtime = item.Episode.Runtime
+		starting = append(starting, startingItem{
+			item: models.UpcomingSchedule{
+				Schedule:        item,
+				StartsInMinutes: int(start.Sub(at).Minutes()),
+				EndsAt:          end.Format(time.RFC3339),
+			},
+			start: start,
+		})
+	}
+	
+	sort.SliceStable(starting, func(i, j int) bool {
+		return starting[i].start.Before(starting[j].start)
+	})
+	
+	upcoming := make([]models.UpcomingSchedule, 0, len(starting))
+	for _, s := range starting {
+		upcoming = append(upcoming, s.item)
+	}
+	return upcoming
+}
+
+// airingWindow calcula os instantes absolutos de início e fim de um item da programação.
+// Usa o airstamp quando disponível; caso contrário, interpreta airdate/airtime no fuso
+// do país da emissora.
+func airingWindow(item models.Schedule) (time.Time, time.Time, bool) {
+	start, err := time.Parse(time.RFC3339, item.Airstamp)
+	if err != nil {
+		if item.Airdate == "" || item.Airtime == "" {
+			return time.Time{}, time.Time{}, false
 		}
-		
-		endTime := airtime.Add(time.Duration(runtime) * time.Minute)
-		currentTimeParsed, _ := time.Parse("15:04", currentTime)
-		
-		// Verificar se está no ar agora
-		if !currentTimeParsed.Before(airtime) && currentTimeParsed.Before(endTime) {
-			nowPlaying = append(nowPlaying, item)
+		start, err = time.ParseInLocation("2006-01-02 15:04", item.Airdate+" "+item.Airtime, ScheduleLocation(item))
+		if err != nil {
+			return time.Time{}, time.Time{}, false
 		}
 	}
 	
-	return nowPlaying, nil
+	runtime := defaultRuntime
+	if item.Runtime > 0 {
+		runtime = time.Duration(item.Runtime) * time.Minute
+	} else if item.Episode != nil && item.Episode.Runtime > 0 {
+		runtime = time.Duration(item.Episode.Runtime) * time.Minute
+	}
+	
+	return start, start.Add(runtime), true
+}
+
+// ScheduleLocation retorna o fuso horário do país da emissora ou canal de streaming
+// de um item da programação, ou UTC quando desconhecido
+func ScheduleLocation(item models.Schedule) *time.Location {
+	var tz string
+	if item.Show.Network != nil {
+		tz = item.Show.Network.Country.Timezone
+	} else if item.Show.WebChannel != nil && item.Show.WebChannel.Country != nil {
+		tz = item.Show.WebChannel.Country.Timezone
+	}
+	
+	if tz == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// countryTimezones é o fuso principal de cada país, usado quando a programação
+// não traz o fuso da emissora (ex: nada passando no horário)
+var countryTimezones = map[string]string{
+	"AR": "America/Argentina/Buenos_Aires",
+	"AU": "Australia/Sydney",
+	"BR": "America/Sao_Paulo",
+	"CA": "America/Toronto",
+	"DE": "Europe/Berlin",
+	"ES": "Europe/Madrid",
+	"FR": "Europe/Paris",
+	"GB": "Europe/London",
+	"IE": "Europe/Dublin",
+	"IN": "Asia/Kolkata",
+	"IT": "Europe/Rome",
+	"JP": "Asia/Tokyo",
+	"KR": "Asia/Seoul",
+	"MX": "America/Mexico_City",
+	"NL": "Europe/Amsterdam",
+	"NZ": "Pacific/Auckland",
+	"PT": "Europe/Lisbon",
+	"US": "America/New_York",
+}
+
+// CountryLocation retorna o fuso principal do país (código ISO de 2 letras);
+// ok é false para países fora da tabela
+func CountryLocation(country string) (loc *time.Location, ok bool) {
+	tz, ok := countryTimezones[strings.ToUpper(country)]
+	if !ok {
+		return time.UTC, false
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.UTC, false
+	}
+	return loc, true
 }
@@ -1,9 +1,13 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github-api-demo/internal/clients"
+	"github-api-demo/internal/models"
 )
 
 func TestNewTVMazeService(t *testing.T) {
@@ -23,7 +27,7 @@ func TestSearchShows_EmptyQuery(t *testing.T) {
 	client := clients.NewTVMazeClient()
 	service := NewTVMazeService(client)
 	
-	_, err := service.SearchShows("")
+	_, err := service.SearchShows(context.Background(), "", SearchFilter{})
 	if err == nil {
 		t.Error("SearchShows deve retornar erro para query vazia")
 	}
@@ -31,13 +35,17 @@ func TestSearchShows_EmptyQuery(t *testing.T) {
 	if err.Error() != "query não pode ser vazia" {
 		t.Errorf("Mensagem de erro incorreta: %v", err)
 	}
+	
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Erro deveria ser de entrada inválida: %v", err)
+	}
 }
 
 func TestGetShowByID_EmptyID(t *testing.T) {
 	client := clients.NewTVMazeClient()
 	service := NewTVMazeService(client)
 	
-	_, err := service.GetShowByID("")
+	_, err := service.GetShowByID(context.Background(), "")
 	if err == nil {
 		t.Error("GetShowByID deve retornar erro para ID vazio")
 	}
@@ -51,7 +59,7 @@ func TestGetScheduleByGenre_EmptyGenre(t *testing.T) {
 	client := clients.NewTVMazeClient()
 	service := NewTVMazeService(client)
 	
-	_, err := service.GetScheduleByGenre("US", "")
+	_, err := service.GetScheduleByGenre(context.Background(), "US", "")
 	if err == nil {
 		t.Error("GetScheduleByGenre deve retornar erro para gênero vazio")
 	}
@@ -60,3 +68,361 @@ func TestGetScheduleByGenre_EmptyGenre(t *testing.T) {
 		t.Errorf("Mensagem de erro incorreta: %v", err)
 	}
 }
+
+func TestLookupShow_InvalidInput(t *testing.T) {
+	client := clients.NewTVMazeClient()
+	service := NewTVMazeService(client)
+	
+	if _, err := service.LookupShow(context.Background(), "imdb", ""); err == nil || err.Error() != "ID não pode ser vazio" {
+		t.Errorf("LookupShow deve retornar erro para ID vazio: %v", err)
+	}
+	
+	if _, err := service.LookupShow(context.Background(), "netflix", "123"); err == nil || err.Error() != "fonte inválida: netflix" {
+		t.Errorf("LookupShow deve retornar erro para fonte inválida: %v", err)
+	}
+}
+
+func TestEpisodeGuide_EmptyID(t *testing.T) {
+	client := clients.NewTVMazeClient()
+	service := NewTVMazeService(client)
+	
+	if _, err := service.GetEpisodes(context.Background(), ""); err == nil || err.Error() != "ID não pode ser vazio" {
+		t.Errorf("GetEpisodes deve retornar erro para ID vazio: %v", err)
+	}
+	
+	if _, err := service.GetSeasons(context.Background(), ""); err == nil || err.Error() != "ID não pode ser vazio" {
+		t.Errorf("GetSeasons deve retornar erro para ID vazio: %v", err)
+	}
+	
+	if _, err := service.GetSeasonEpisodes(context.Background(), ""); err == nil || err.Error() != "ID não pode ser vazio" {
+		t.Errorf("GetSeasonEpisodes deve retornar erro para ID vazio: %v", err)
+	}
+}
+
+func TestEpisodeGuide_NonNumericID(t *testing.T) {
+	client := &fakeTVMaze{}
+	service := NewTVMazeService(client)
+	
+	for _, id := range []string{"1?x", "1/cast", "../people/1", "-1"} {
+		if _, err := service.GetEpisodes(context.Background(), id); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("GetEpisodes(%q) deveria rejeitar ID não numérico: %v", id, err)
+		}
+		if _, err := service.GetSeasonEpisodes(context.Background(), id); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("GetSeasonEpisodes(%q) deveria rejeitar ID não numérico: %v", id, err)
+		}
+		if _, err := service.GetShowByID(context.Background(), id); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("GetShowByID(%q) deveria rejeitar ID não numérico: %v", id, err)
+		}
+	}
+}
+
+func TestCastAndCrew_EmptyID(t *testing.T) {
+	client := clients.NewTVMazeClient()
+	service := NewTVMazeService(client)
+	
+	if _, err := service.GetCast(context.Background(), ""); err == nil || err.Error() != "ID não pode ser vazio" {
+		t.Errorf("GetCast deve retornar erro para ID vazio: %v", err)
+	}
+	
+	if _, err := service.GetCrew(context.Background(), ""); err == nil || err.Error() != "ID não pode ser vazio" {
+		t.Errorf("GetCrew deve retornar erro para ID vazio: %v", err)
+	}
+	
+	if _, err := service.GetCast(context.Background(), "1?x"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetCast deve rejeitar ID não numérico: %v", err)
+	}
+	
+	if _, err := service.GetCrew(context.Background(), "1/episodes"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetCrew deve rejeitar ID não numérico: %v", err)
+	}
+}
+
+func TestFilterSearchResults(t *testing.T) {
+	results := []models.SearchResult{
+		{Score: 0.9, Show: models.Show{Name: "Friends", Language: "English", Genres: []string{"Comedy", "Romance"}}},
+		{Score: 0.7, Show: models.Show{Name: "Amigos", Language: "Spanish", Genres: []string{"Comedy"}}},
+		{Score: 0.5, Show: models.Show{Name: "Friends Forever", Language: "English", Genres: []string{"Drama"}}},
+		{Score: 0.2, Show: models.Show{Name: "Old Friends", Language: "English", Genres: []string{"Comedy"}}},
+	}
+	
+	tests := []struct {
+		name   string
+		filter SearchFilter
+		want   []string
+	}{
+		{"sem filtros", SearchFilter{}, []string{"Friends", "Amigos", "Friends Forever", "Old Friends"}},
+		{"score mínimo", SearchFilter{MinScore: 0.5}, []string{"Friends", "Amigos", "Friends Forever"}},
+		{"gênero", SearchFilter{Genre: "comedy"}, []string{"Friends", "Amigos", "Old Friends"}},
+		{"idioma", SearchFilter{Language: "english"}, []string{"Friends", "Friends Forever", "Old Friends"}},
+		{"limite", SearchFilter{Limit: 2}, []string{"Friends", "Amigos"}},
+		{"combinados", SearchFilter{Genre: "Comedy", Language: "English", Limit: 1}, []string{"Friends"}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterSearchResults(results, tt.filter)
+			if len(got) != len(tt.want) {
+				t.Fatalf("esperado %d resultados, obtido %d", len(tt.want), len(got))
+			}
+			for i, name := range tt.want {
+				if got[i].Show.Name != name {
+					t.Errorf("resultado %d: esperado %q, obtido %q", i, name, got[i].Show.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestGetScheduleRange_InvalidRange(t *testing.T) {
+	client := clients.NewTVMazeClient()
+	service := NewTVMazeService(client)
+	
+	from := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	
+	if _, err := service.GetScheduleRange(context.Background(), "US", SourceTV, from, from.AddDate(0, 0, -1)); err == nil {
+		t.Error("GetScheduleRange deve retornar erro quando a data final é anterior à inicial")
+	}
+	
+	if _, err := service.GetScheduleRange(context.Background(), "US", SourceTV, from, from.AddDate(0, 0, MaxScheduleDays)); err == nil {
+		t.Error("GetScheduleRange deve retornar erro para períodos maiores que o máximo")
+	}
+	
+	far := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	if _, err := service.GetScheduleRange(context.Background(), "US", SourceTV, time.Time{}, far); err == nil {
+		t.Error("GetScheduleRange deve rejeitar períodos de séculos sem montar a lista de datas")
+	}
+	
+	if _, err := service.GetScheduleRange(context.Background(), "US", "cable", from, from); err == nil || err.Error() != "fonte inválida: cable" {
+		t.Errorf("GetScheduleRange deve retornar erro para fonte inválida: %v", err)
+	}
+}
+
+func TestGetScheduleRange_AllSourcesMergesTVAndWeb(t *testing.T) {
+	client := &fakeTVMaze{
+		schedules: map[string][]models.Schedule{
+			"2024-03-01": {{ID: 1, Airdate: "2024-03-01", Airtime: "20:00"}},
+			"2024-03-02": {{ID: 2, Airdate: "2024-03-02", Airtime: "09:00"}},
+		},
+		webSchedules: map[string][]models.Schedule{
+			"2024-03-01": {{ID: 3, Airdate: "2024-03-01", Airtime: "00:00"}},
+			"2024-03-02": {{ID: 4, Airdate: "2024-03-02", Airtime: "21:00"}},
+		},
+	}
+	service := NewTVMazeService(client)
+	
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	schedule, err := service.GetScheduleRange(context.Background(), "US", SourceAll, from, from.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	
+	var ids []int
+	for _, s := range schedule {
+		ids = append(ids, s.ID)
+	}
+	if len(ids) != 4 || ids[0] != 3 || ids[1] != 1 || ids[2] != 2 || ids[3] != 4 {
+		t.Errorf("TV e streaming deveriam ser unidos em ordem de data e horário: %v", ids)
+	}
+	if len(client.calls) != 4 {
+		t.Errorf("esperadas 4 chamadas (TV e web por dia), obtido %v", client.calls)
+	}
+}
+
+func TestGetScheduleRange_WebPropagatesClientError(t *testing.T) {
+	client := &fakeTVMaze{err: clients.NewError(ErrUpstreamUnavailable, "status code: 503")}
+	service := NewTVMazeService(client)
+	
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := service.GetScheduleRange(context.Background(), "US", SourceWeb, from, from); !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Errorf("erro da programação web deveria ser propagado, obtido %v", err)
+	}
+}
+
+func TestSchedule_RejectsInvalidCountry(t *testing.T) {
+	client := &fakeTVMaze{}
+	service := NewTVMazeService(client)
+	
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, country := range []string{"US&date=2020-01-01", "US#", "USA", "", "1B"} {
+		if _, err := service.GetScheduleRange(context.Background(), country, SourceAll, from, from); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("país %q deveria ser rejeitado: %v", country, err)
+		}
+		if _, err := service.GetTodaySchedule(context.Background(), country); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("país %q deveria ser rejeitado: %v", country, err)
+		}
+	}
+	if len(client.calls) != 0 {
+		t.Errorf("nenhuma chamada deveria chegar ao cliente: %v", client.calls)
+	}
+}
+
+func TestSortSchedule(t *testing.T) {
+	schedule := []models.Schedule{
+		{ID: 1, Airdate: "2024-01-11", Airtime: "08:00"},
+		{ID: 2, Airdate: "2024-01-10", Airtime: "21:00"},
+		{ID: 3, Airdate: "2024-01-10", Airtime: "09:30"},
+		{ID: 4, Airdate: "2024-01-11", Airtime: "08:00"},
+	}
+	
+	sortSchedule(schedule)
+	
+	want := []int{3, 2, 1, 4}
+	for i, id := range want {
+		if schedule[i].ID != id {
+			t.Errorf("posição %d: esperado ID %d, obtido %d", i, id, schedule[i].ID)
+		}
+	}
+}
+
+func TestPlayingAt(t *testing.T) {
+	newYork := models.Show{Network: &models.Network{Country: models.Country{Code: "US", Timezone: "America/New_York"}}}
+	
+	schedule := []models.Schedule{
+		// 20:00 em Nova York = 01:00 UTC do dia seguinte
+		{ID: 1, Airstamp: "2024-01-10T01:00:00+00:00", Runtime: 60, Show: newYork},
+		// Atravessa a meia-noite UTC: 23:30 -> 00:30
+		{ID: 2, Airstamp: "2024-01-09T23:30:00+00:00", Runtime: 60, Show: newYork},
+		// Sem airstamp: usa airdate/airtime no fuso da emissora (19:00 EST = 00:00 UTC)
+		{ID: 3, Airdate: "2024-01-09", Airtime: "19:00", Runtime: 30, Show: newYork},
+		// Já terminou
+		{ID: 4, Airstamp: "2024-01-09T22:00:00+00:00", Runtime: 30, Show: newYork},
+		// Sem horário
+		{ID: 5, Show: newYork},
+	}
+	
+	at := time.Date(2024, 1, 10, 0, 15, 0, 0, time.UTC)
+	playing := playingAt(schedule, at)
+	
+	want := []int{2, 3}
+	if len(playing) != len(want) {
+		t.Fatalf("esperado %d programas no ar, obtido %d", len(want), len(playing))
+	}
+	for i, id := range want {
+		if playing[i].ID != id {
+			t.Errorf("posição %d: esperado ID %d, obtido %d", i, id, playing[i].ID)
+		}
+	}
+}
+
+func TestUpcomingAt(t *testing.T) {
+	schedule := []models.Schedule{
+		{ID: 1, Airstamp: "2024-01-10T02:00:00+00:00", Runtime: 30},
+		{ID: 2, Airstamp: "2024-01-10T00:30:00+00:00", Runtime: 45},
+		{ID: 3, Airstamp: "2024-01-10T00:00:00+00:00", Runtime: 60}, // já começou
+		{ID: 4, Airstamp: "2024-01-10T03:00:00+00:00", Runtime: 60}, // fora da janela
+	}
+	
+	at := time.Date(2024, 1, 10, 0, 15, 0, 0, time.UTC)
+	upcoming := upcomingAt(schedule, at, 2*time.Hour)
+	
+	if len(upcoming) != 2 {
+		t.Fatalf("esperado 2 programas, obtido %d", len(upcoming))
+	}
+	
+	if upcoming[0].ID != 2 || upcoming[0].StartsInMinutes != 15 || upcoming[0].EndsAt != "2024-01-10T01:15:00Z" {
+		t.Errorf("primeiro item incorreto: %+v", upcoming[0])
+	}
+	
+	if upcoming[1].ID != 1 || upcoming[1].StartsInMinutes != 105 {
+		t.Errorf("segundo item incorreto: %+v", upcoming[1])
+	}
+}
+
+func TestGetUpcoming_InvalidWindow(t *testing.T) {
+	client := clients.NewTVMazeClient()
+	service := NewTVMazeService(client)
+	
+	if _, err := service.GetUpcoming(context.Background(), "US", 0); err == nil {
+		t.Error("GetUpcoming deve retornar erro para janela vazia")
+	}
+	
+	if _, err := service.GetUpcoming(context.Background(), "US", MaxUpcomingWindow+time.Minute); err == nil {
+		t.Error("GetUpcoming deve retornar erro para janela acima do máximo")
+	}
+}
+
+func TestGetScheduleByGenre_FiltersTodaySchedule(t *testing.T) {
+	client := &fakeTVMaze{schedules: map[string][]models.Schedule{
+		"2024-03-01": {
+			{ID: 1, Show: models.Show{Name: "Jornal", Genres: []string{"News"}}},
+			{ID: 2, Show: models.Show{Name: "Futebol", Genres: []string{"Sports"}}},
+			{ID: 3, Show: models.Show{Name: "Sem gênero"}},
+			{ID: 4, Show: models.Show{Name: "Mesa Redonda", Genres: []string{"Talk Show", "sports"}}},
+		},
+	}}
+	service := NewTVMazeService(client)
+	service.SetClock(func() time.Time { return time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC) })
+
+	schedule, err := service.GetScheduleByGenre(context.Background(), "BR", "SPORTS")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(schedule) != 2 || schedule[0].ID != 2 || schedule[1].ID != 4 {
+		t.Errorf("filtro por gênero incorreto: %+v", schedule)
+	}
+	if len(client.calls) != 1 || client.calls[0] != "BR:2024-03-01" {
+		t.Errorf("chamadas inesperadas ao cliente: %v", client.calls)
+	}
+}
+
+func TestGetScheduleByGenre_PropagatesClientError(t *testing.T) {
+	client := &fakeTVMaze{err: clients.NewError(ErrUpstreamUnavailable, "status code: 503")}
+	service := NewTVMazeService(client)
+
+	_, err := service.GetScheduleByGenre(context.Background(), "US", "Drama")
+	if !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Errorf("erro do cliente deveria ser propagado, obtido %v", err)
+	}
+}
+
+func TestGetNowPlaying_ReturnsOnlyAiringShows(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 10, 0, 0, time.UTC)
+	item := func(id int, start time.Time, runtime int) models.Schedule {
+		return models.Schedule{ID: id, Airstamp: start.Format(time.RFC3339), Runtime: runtime}
+	}
+	byDate := map[string][]models.Schedule{}
+	for _, s := range []models.Schedule{
+		item(1, now.Add(-30*time.Minute), 60),
+		item(2, now.Add(-2*time.Hour), 60),
+		item(3, now.Add(30*time.Minute), 60),
+		item(4, now.Add(-24*time.Hour), 24*60+30),
+	} {
+		start, _ := time.Parse(time.RFC3339, s.Airstamp)
+		date := start.Format("2006-01-02")
+		byDate[date] = append(byDate[date], s)
+	}
+	service := NewTVMazeService(&fakeTVMaze{schedules: byDate})
+	service.SetClock(func() time.Time { return now })
+
+	playing, err := service.GetNowPlaying(context.Background(), "US")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	ids := map[int]bool{}
+	for _, p := range playing {
+		ids[p.ID] = true
+	}
+	if len(playing) != 2 || !ids[1] || !ids[4] {
+		t.Errorf("esperado os itens 1 e 4 no ar, obtido %+v", playing)
+	}
+}
+
+func TestGetPlayingAt_PreviewsFutureTime(t *testing.T) {
+	client := &fakeTVMaze{schedules: map[string][]models.Schedule{
+		"2024-03-02": {
+			{ID: 1, Airstamp: "2024-03-02T00:00:00Z", Runtime: 60},
+			{ID: 2, Airstamp: "2024-03-02T01:00:00Z", Runtime: 30},
+		},
+	}}
+	service := NewTVMazeService(client)
+	service.SetClock(func() time.Time { return time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC) })
+
+	playing, err := service.GetPlayingAt(context.Background(), "US", time.Date(2024, 3, 2, 1, 15, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(playing) != 1 || playing[0].ID != 2 {
+		t.Errorf("esperado apenas o item 2 no ar, obtido %+v", playing)
+	}
+}
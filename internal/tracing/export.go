@@ -0,0 +1,311 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Exporter envia lotes de spans finalizados para algum destino
+type Exporter interface {
+	Export(ctx context.Context, spans []SpanData) error
+}
+
+// Parâmetros do envio em lote
+const (
+	queueSize     = 2048
+	batchSize     = 256
+	flushInterval = 2 * time.Second
+)
+
+// batcher acumula spans e os entrega ao exportador em lotes, fora do caminho
+// das requisições. Com a fila cheia, novos spans são descartados.
+type batcher struct {
+	exporter Exporter
+	queue    chan SpanData
+	done     chan struct{}
+	once     sync.Once
+}
+
+var (
+	activeMu sync.RWMutex
+	active   *batcher
+)
+
+// export entrega o span ao batcher ativo, se houver. A trava fica mantida durante
+// o envio para que shutdown não feche a fila no meio dele.
+func export(span SpanData) {
+	activeMu.RLock()
+	defer activeMu.RUnlock()
+	if active == nil {
+		return
+	}
+	select {
+	case active.queue <- span:
+	default:
+	}
+}
+
+// Configure passa a exportar os spans pelo exportador informado e retorna a
+// função que envia os spans pendentes e encerra a exportação. Exportador nil
+// desativa a exportação.
+func Configure(exporter Exporter) (shutdown func(context.Context) error) {
+	if exporter == nil {
+		activeMu.Lock()
+		active = nil
+		activeMu.Unlock()
+		return func(context.Context) error { return nil }
+	}
+
+	b := &batcher{
+		exporter: exporter,
+		queue:    make(chan SpanData, queueSize),
+		done:     make(chan struct{}),
+	}
+	activeMu.Lock()
+	active = b
+	activeMu.Unlock()
+
+	go b.run()
+	return b.shutdown
+}
+
+// run junta os spans da fila e os exporta a cada batchSize spans ou flushInterval
+func (b *batcher) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	var batch []SpanData
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := b.exporter.Export(ctx, batch); err != nil {
+			slog.Warn("falha ao exportar spans", "spans", len(batch), "error", err)
+		}
+		cancel()
+		batch = nil
+	}
+
+	for {
+		select {
+		case span, ok := <-b.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, span)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// shutdown para de aceitar spans e aguarda o envio dos pendentes até o prazo de ctx
+func (b *batcher) shutdown(ctx context.Context) error {
+	b.once.Do(func() {
+		// Fechada sob a trava: depois disso nenhum export enxerga este batcher
+		activeMu.Lock()
+		if active == b {
+			active = nil
+		}
+		close(b.queue)
+		activeMu.Unlock()
+	})
+
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// StdoutExporter escreve cada span como uma linha JSON, para depuração local
+type StdoutExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewStdoutExporter cria um exportador que escreve em w
+func NewStdoutExporter(w io.Writer) *StdoutExporter {
+	return &StdoutExporter{w: w}
+}
+
+// Export escreve os spans do lote
+func (e *StdoutExporter) Export(ctx context.Context, spans []SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	enc := json.NewEncoder(e.w)
+	for _, span := range spans {
+		attrs := make(map[string]interface{}, len(span.Attributes))
+		for _, a := range span.Attributes {
+			attrs[a.Key] = a.Value
+		}
+		line := map[string]interface{}{
+			"name":        span.Name,
+			"kind":        span.Kind,
+			"trace_id":    span.TraceID.String(),
+			"span_id":     span.SpanID.String(),
+			"start":       span.Start.Format(time.RFC3339Nano),
+			"duration_ms": float64(span.End.Sub(span.Start).Microseconds()) / 1000,
+			"attributes":  attrs,
+			"status":      span.StatusCode,
+		}
+		if span.ParentSpanID.IsValid() {
+			line["parent_span_id"] = span.ParentSpanID.String()
+		}
+		if span.StatusMessage != "" {
+			line["status_message"] = span.StatusMessage
+		}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OTLPExporter envia os spans para um coletor OpenTelemetry via OTLP/HTTP com
+// codificação JSON (ex: http://localhost:4318/v1/traces)
+type OTLPExporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+}
+
+// NewOTLPExporter cria um exportador para a URL completa de traces do coletor
+func NewOTLPExporter(url, serviceName string) *OTLPExporter {
+	return &OTLPExporter{
+		url:         url,
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Export envia o lote ao coletor
+func (e *OTLPExporter) Export(ctx context.Context, spans []SpanData) error {
+	body, err := json.Marshal(e.payload(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("coletor OTLP respondeu status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Estruturas do ExportTraceServiceRequest no mapeamento JSON do OTLP
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string          `json:"traceId"`
+		SpanID            string          `json:"spanId"`
+		ParentSpanID      string          `json:"parentSpanId,omitempty"`
+		Name              string          `json:"name"`
+		Kind              SpanKind        `json:"kind"`
+		StartTimeUnixNano string          `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+		Attributes        []otlpAttribute `json:"attributes,omitempty"`
+		Status            otlpStatus      `json:"status"`
+	}
+	otlpStatus struct {
+		Code    StatusCode `json:"code,omitempty"`
+		Message string     `json:"message,omitempty"`
+	}
+	otlpAttribute struct {
+		Key   string                 `json:"key"`
+		Value map[string]interface{} `json:"value"`
+	}
+)
+
+// payload converte os spans para o formato OTLP/JSON
+func (e *OTLPExporter) payload(spans []SpanData) otlpRequest {
+	converted := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		s := otlpSpan{
+			TraceID:           span.TraceID.String(),
+			SpanID:            span.SpanID.String(),
+			Name:              span.Name,
+			Kind:              span.Kind,
+			StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+			Status:            otlpStatus{Code: span.StatusCode, Message: span.StatusMessage},
+		}
+		if span.ParentSpanID.IsValid() {
+			s.ParentSpanID = span.ParentSpanID.String()
+		}
+		for _, a := range span.Attributes {
+			s.Attributes = append(s.Attributes, otlpAttr(a.Key, a.Value))
+		}
+		converted = append(converted, s)
+	}
+
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: []otlpAttribute{otlpAttr("service.name", e.serviceName)}},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "github-api-demo"}, Spans: converted}},
+	}}}
+}
+
+// otlpAttr converte um valor Go para o AnyValue do OTLP
+func otlpAttr(key string, value interface{}) otlpAttribute {
+	var v map[string]interface{}
+	switch x := value.(type) {
+	case bool:
+		v = map[string]interface{}{"boolValue": x}
+	case int:
+		v = map[string]interface{}{"intValue": strconv.Itoa(x)}
+	case int64:
+		v = map[string]interface{}{"intValue": strconv.FormatInt(x, 10)}
+	case float64:
+		v = map[string]interface{}{"doubleValue": x}
+	case string:
+		v = map[string]interface{}{"stringValue": x}
+	default:
+		v = map[string]interface{}{"stringValue": fmt.Sprint(x)}
+	}
+	return otlpAttribute{Key: key, Value: v}
+}
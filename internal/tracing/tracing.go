@@ -0,0 +1,263 @@
+// Package tracing cria spans compatíveis com o OpenTelemetry sem dependências
+// externas: IDs de trace/span, propagação W3C traceparent e exportação dos spans
+// finalizados (OTLP/HTTP, stdout ou nenhuma).
+//
+// Sem Configure, os spans são criados e propagados, mas não exportados.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceID identifica um trace (16 bytes)
+type TraceID [16]byte
+
+// SpanID identifica um span (8 bytes)
+type SpanID [8]byte
+
+func (id TraceID) String() string { return hex.EncodeToString(id[:]) }
+func (id SpanID) String() string  { return hex.EncodeToString(id[:]) }
+
+// IsValid indica se o ID não é nulo
+func (id TraceID) IsValid() bool { return id != TraceID{} }
+
+// IsValid indica se o ID não é nulo
+func (id SpanID) IsValid() bool { return id != SpanID{} }
+
+// SpanKind segue os valores do OTLP
+type SpanKind int
+
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+	KindClient   SpanKind = 3
+)
+
+// StatusCode segue os valores do OTLP
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = 0
+	StatusOK    StatusCode = 1
+	StatusError StatusCode = 2
+)
+
+// SpanContext é a parte do span que atravessa processos pelo header traceparent
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid indica se o contexto tem IDs de trace e span
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Attribute é um atributo do span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// SpanData é o span finalizado, entregue ao exportador
+type SpanData struct {
+	Name          string
+	Kind          SpanKind
+	TraceID       TraceID
+	SpanID        SpanID
+	ParentSpanID  SpanID
+	Start         time.Time
+	End           time.Time
+	Attributes    []Attribute
+	StatusCode    StatusCode
+	StatusMessage string
+}
+
+// Span é uma operação em andamento. Todos os métodos aceitam span nil.
+type Span struct {
+	mu      sync.Mutex
+	data    SpanData
+	sampled bool
+	ended   bool
+}
+
+// SpanContext retorna o contexto propagável do span
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return SpanContext{TraceID: s.data.TraceID, SpanID: s.data.SpanID, Sampled: s.sampled}
+}
+
+// SetAttributes acrescenta atributos em pares chave, valor
+func (s *Span) SetAttributes(keyValues ...interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		key, _ := keyValues[i].(string)
+		s.data.Attributes = append(s.data.Attributes, Attribute{Key: key, Value: keyValues[i+1]})
+	}
+}
+
+// RecordError marca o span com erro; err nil não faz nada
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.SetStatus(StatusError, err.Error())
+}
+
+// SetStatus define o status do span
+func (s *Span) SetStatus(code StatusCode, message string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.StatusCode = code
+	s.data.StatusMessage = message
+}
+
+// End finaliza o span e o entrega ao exportador, se amostrado
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	data.Attributes = append([]Attribute(nil), s.data.Attributes...)
+	s.mu.Unlock()
+
+	if s.sampled {
+		export(data)
+	}
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+// Start cria um span filho do span (ou do contexto remoto) presente em ctx
+// e retorna um contexto que o carrega
+func Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	parent := SpanContextFromContext(ctx)
+
+	span := &Span{sampled: true}
+	span.data = SpanData{Name: name, Kind: kind, Start: time.Now(), SpanID: newSpanID()}
+	if parent.IsValid() {
+		span.data.TraceID = parent.TraceID
+		span.data.ParentSpanID = parent.SpanID
+		span.sampled = parent.Sampled
+	} else {
+		span.data.TraceID = newTraceID()
+	}
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// FromContext retorna o span atual do contexto, ou nil
+func FromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// SpanContextFromContext retorna o contexto do span atual ou, na falta dele,
+// o contexto remoto extraído de um traceparent
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := FromContext(ctx); span != nil {
+		return span.SpanContext()
+	}
+	sc, _ := ctx.Value(remoteKey{}).(SpanContext)
+	return sc
+}
+
+// TraceparentHeader é o header W3C Trace Context
+const TraceparentHeader = "traceparent"
+
+// Extract lê o traceparent dos headers e, se válido, o coloca no contexto como pai
+func Extract(ctx context.Context, header http.Header) context.Context {
+	sc, ok := ParseTraceparent(header.Get(TraceparentHeader))
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// Inject escreve o traceparent do span atual nos headers
+func Inject(ctx context.Context, header http.Header) {
+	if sc := SpanContextFromContext(ctx); sc.IsValid() {
+		header.Set(TraceparentHeader, FormatTraceparent(sc))
+	}
+}
+
+// FormatTraceparent monta o header no formato 00-<trace-id>-<span-id>-<flags>
+func FormatTraceparent(sc SpanContext) string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceparent interpreta um header traceparent. Versões futuras são aceitas
+// desde que o início siga o formato da versão 00, como pede a especificação.
+func ParseTraceparent(value string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return SpanContext{}, false
+	}
+
+	var sc SpanContext
+	version, err0 := hex.DecodeString(parts[0])
+	traceID, err1 := decodeLower(parts[1], len(sc.TraceID))
+	spanID, err2 := decodeLower(parts[2], len(sc.SpanID))
+	flags, err3 := decodeLower(parts[3], 1)
+	if err0 != nil || len(version) != 1 || err1 != nil || err2 != nil || err3 != nil {
+		return SpanContext{}, false
+	}
+
+	copy(sc.TraceID[:], traceID)
+	copy(sc.SpanID[:], spanID)
+	sc.Sampled = flags[0]&0x01 == 0x01
+	return sc, sc.IsValid()
+}
+
+// decodeLower decodifica hexadecimal minúsculo com o tamanho exato em bytes
+func decodeLower(s string, size int) ([]byte, error) {
+	if len(s) != size*2 || strings.ToLower(s) != s {
+		return nil, fmt.Errorf("hexadecimal inválido: %q", s)
+	}
+	return hex.DecodeString(s)
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		rand.Read(id[:])
+	}
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		rand.Read(id[:])
+	}
+	return id
+}
@@ -0,0 +1,180 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	const valid = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	sc, ok := ParseTraceparent(valid)
+	if !ok {
+		t.Fatalf("traceparent válido rejeitado: %s", valid)
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" || !sc.Sampled {
+		t.Errorf("contexto incorreto: %+v", sc)
+	}
+	if got := FormatTraceparent(sc); got != valid {
+		t.Errorf("FormatTraceparent = %q, esperado %q", got, valid)
+	}
+
+	for _, v := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	} {
+		if _, ok := ParseTraceparent(v); ok {
+			t.Errorf("traceparent inválido aceito: %q", v)
+		}
+	}
+}
+
+func TestStart_ContinuesRemoteTrace(t *testing.T) {
+	header := http.Header{}
+	header.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	ctx, server := Start(Extract(context.Background(), header), "GET /show", KindServer)
+	_, client := Start(ctx, "GET", KindClient)
+
+	if server.data.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || server.data.ParentSpanID.String() != "00f067aa0ba902b7" {
+		t.Errorf("o span de servidor deveria continuar o trace remoto: %+v", server.data)
+	}
+	if client.data.TraceID != server.data.TraceID || client.data.ParentSpanID != server.data.SpanID {
+		t.Errorf("o span de cliente deveria ser filho do span de servidor: %+v", client.data)
+	}
+
+	out := http.Header{}
+	Inject(ctx, out)
+	sc, ok := ParseTraceparent(out.Get(TraceparentHeader))
+	if !ok || sc.SpanID != server.data.SpanID {
+		t.Errorf("traceparent propagado incorreto: %q", out.Get(TraceparentHeader))
+	}
+}
+
+// recordingExporter guarda os spans exportados
+type recordingExporter struct {
+	spans chan SpanData
+}
+
+func (e *recordingExporter) Export(ctx context.Context, spans []SpanData) error {
+	for _, s := range spans {
+		e.spans <- s
+	}
+	return nil
+}
+
+func TestConfigure_ExportsEndedSpans(t *testing.T) {
+	exp := &recordingExporter{spans: make(chan SpanData, 10)}
+	shutdown := Configure(exp)
+
+	_, span := Start(context.Background(), "TVMazeService.GetShowByID", KindInternal)
+	span.SetAttributes("show.id", "431")
+	span.End()
+	span.End()
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(exp.spans) != 1 {
+		t.Fatalf("esperado 1 span exportado, obtido %d", len(exp.spans))
+	}
+	got := <-exp.spans
+	if got.Name != "TVMazeService.GetShowByID" || len(got.Attributes) != 1 || got.End.IsZero() {
+		t.Errorf("span exportado incorreto: %+v", got)
+	}
+
+	// Após o shutdown, os spans não são mais exportados
+	_, span = Start(context.Background(), "depois", KindInternal)
+	span.End()
+	if len(exp.spans) != 0 {
+		t.Error("span exportado após o shutdown")
+	}
+}
+
+// discardExporter descarta os spans exportados
+type discardExporter struct{}
+
+func (discardExporter) Export(ctx context.Context, spans []SpanData) error { return nil }
+
+func TestConfigure_SpansEndingDuringShutdown(t *testing.T) {
+	exp := discardExporter{}
+	shutdown := Configure(exp)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				_, span := Start(context.Background(), "fetch", KindInternal)
+				span.End()
+			}
+		}()
+	}
+
+	// Encerrar com spans ainda terminando não pode causar panic na fila fechada
+	time.Sleep(10 * time.Millisecond)
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(stop)
+	wg.Wait()
+}
+
+func TestOTLPExporter_PostsJSON(t *testing.T) {
+	var body map[string]interface{}
+	var path, contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, contentType = r.URL.Path, r.Header.Get("Content-Type")
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer srv.Close()
+
+	start := time.Unix(1700000000, 0)
+	span := SpanData{
+		Name:       "GET /show",
+		Kind:       KindServer,
+		TraceID:    newTraceID(),
+		SpanID:     newSpanID(),
+		Start:      start,
+		End:        start.Add(time.Second),
+		Attributes: []Attribute{{Key: "http.response.status_code", Value: 200}},
+	}
+	exp := NewOTLPExporter(srv.URL+"/v1/traces", "tvmaze-api")
+	if err := exp.Export(context.Background(), []SpanData{span}); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if path != "/v1/traces" || contentType != "application/json" {
+		t.Errorf("requisição incorreta: %s %s", path, contentType)
+	}
+	raw, _ := json.Marshal(body)
+	for _, want := range []string{
+		`"stringValue":"tvmaze-api"`,
+		`"traceId":"` + span.TraceID.String() + `"`,
+		`"startTimeUnixNano":"1700000000000000000"`,
+		`"intValue":"200"`,
+		`"kind":2`,
+	} {
+		if !bytes.Contains(raw, []byte(want)) {
+			t.Errorf("payload sem %s: %s", want, raw)
+		}
+	}
+}
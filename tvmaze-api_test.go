@@ -4,10 +4,38 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
 	"testing"
 	"time"
+
+	"github-api-demo/internal/fakemaze"
 )
 
+// TestMain redireciona as chamadas à api.tvmaze.com para o fakemaze, para que os
+// testes rodem sem rede. Rode com: go test tvmaze-api.go tvmaze-api_test.go
+func TestMain(m *testing.M) {
+	srv := httptest.NewServer(fakemaze.New(fakemaze.Config{}))
+	target, _ := url.Parse(srv.URL)
+	httpClient.Transport = redirectTransport{target: target}
+
+	code := m.Run()
+	srv.Close()
+	os.Exit(code)
+}
+
+// redirectTransport envia as requisições ao host de target, mantendo caminho e query
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
 func TestHomeHandler(t *testing.T) {
 	req, err := http.NewRequest("GET", "/", nil)
 	if err != nil {